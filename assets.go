@@ -0,0 +1,217 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/png" // Sprite sheets are PNG files
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// spriteManifestFile lists the sprite sheets of an assets directory
+const spriteManifestFile = "sprites.json"
+
+//go:embed assets
+var embeddedAssets embed.FS
+
+// Default animation of each kind of sprite; towers idle and enemies walk
+const (
+	towerAnimation = "idle"
+	enemyAnimation = "walk"
+)
+
+// Optional animation a tower plays once each time it fires
+const fireAnimation = "fire"
+
+// AnimationDef describes one animation in a sprite sheet: a run of frames along a row
+type AnimationDef struct {
+	Row       int     `json:"row"`        // Row of the sheet, counted from the top
+	Start     int     `json:"start"`      // First frame of the row
+	Frames    int     `json:"frames"`     // Number of frames
+	FrameTime float64 `json:"frame_time"` // Seconds per frame
+}
+
+// SpriteSheetDef is a manifest entry: a PNG sheet cut into equal frames and its animations
+type SpriteSheetDef struct {
+	Image       string                  `json:"image"` // Path relative to the assets directory
+	FrameWidth  int                     `json:"frame_width"`
+	FrameHeight int                     `json:"frame_height"`
+	AnchorX     *float64                `json:"anchor_x,omitempty"` // Point of the frame placed on the object (0-1, default center)
+	AnchorY     *float64                `json:"anchor_y,omitempty"`
+	Rotate      bool                    `json:"rotate"` // Turn the frames to the tower's aim; frames face right
+	Animations  map[string]AnimationDef `json:"animations"`
+}
+
+// Animation is a loaded run of frames
+type Animation struct {
+	Frames    []*ebiten.Image
+	FrameTime float64
+}
+
+// spriteKey names the manifest entry for a tower type ("tower_1" ... "tower_9")
+func spriteKey(towerType int) string {
+	return fmt.Sprintf("tower_%d", towerType)
+}
+
+// LoadSprites loads the embedded sprite sheets, then those in dir, which replace embedded
+// sheets of the same name. Sheets that fail to load are skipped so their objects keep the
+// vector drawing; the returned error lists them.
+func (gm *GraphicsManager) LoadSprites(dir string) error {
+	var problems []string
+
+	sources := []fs.FS{}
+	if sub, err := fs.Sub(embeddedAssets, "assets"); err == nil {
+		sources = append(sources, sub)
+	}
+	if dir != "" {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			sources = append(sources, os.DirFS(dir))
+		}
+	}
+
+	for _, source := range sources {
+		manifest, err := readSpriteManifest(source)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		names := make([]string, 0, len(manifest))
+		for name := range manifest {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sprite, animation := gm.spriteFor(name)
+			if sprite == nil {
+				problems = append(problems, fmt.Sprintf("%s: unknown sprite", name))
+				continue
+			}
+			if err := sprite.loadSheet(source, manifest[name], animation); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid sprites: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// readSpriteManifest parses the manifest of an assets directory; a missing one is empty
+func readSpriteManifest(source fs.FS) (map[string]SpriteSheetDef, error) {
+	data, err := fs.ReadFile(source, spriteManifestFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := make(map[string]SpriteSheetDef)
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", spriteManifestFile, err)
+	}
+	return manifest, nil
+}
+
+// spriteFor returns the sprite a manifest entry belongs to and the animation it must have
+func (gm *GraphicsManager) spriteFor(name string) (*Sprite, string) {
+	if name == "enemy" {
+		return gm.EnemySprite, enemyAnimation
+	}
+	for towerType, sprite := range gm.TowerSprites {
+		if name == spriteKey(towerType) {
+			return sprite, towerAnimation
+		}
+	}
+	return nil, ""
+}
+
+// loadSheet cuts a sprite sheet into its animations and makes it the sprite's image; the
+// required animation then drives the sprite's frame counter
+func (s *Sprite) loadSheet(source fs.FS, def SpriteSheetDef, required string) error {
+	if def.FrameWidth <= 0 || def.FrameHeight <= 0 {
+		return fmt.Errorf("frame size must be positive")
+	}
+
+	file, err := source.Open(path.Clean(def.Image))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	decoded, _, err := image.Decode(file)
+	if err != nil {
+		return fmt.Errorf("decoding %s: %v", def.Image, err)
+	}
+
+	sheet := ebiten.NewImageFromImage(decoded)
+	bounds := sheet.Bounds()
+	animations := make(map[string]*Animation)
+	for name, anim := range def.Animations {
+		if anim.Frames <= 0 || anim.FrameTime <= 0 {
+			return fmt.Errorf("animation %q needs frames and a frame_time", name)
+		}
+		y := anim.Row * def.FrameHeight
+		if anim.Row < 0 || anim.Start < 0 || y+def.FrameHeight > bounds.Dy() ||
+			(anim.Start+anim.Frames)*def.FrameWidth > bounds.Dx() {
+			return fmt.Errorf("animation %q lies outside the %dx%d sheet", name, bounds.Dx(), bounds.Dy())
+		}
+
+		frames := make([]*ebiten.Image, anim.Frames)
+		for i := range frames {
+			x := (anim.Start + i) * def.FrameWidth
+			frames[i] = sheet.SubImage(image.Rect(x, y, x+def.FrameWidth, y+def.FrameHeight)).(*ebiten.Image)
+		}
+		animations[name] = &Animation{Frames: frames, FrameTime: anim.FrameTime}
+	}
+	if animations[required] == nil {
+		return fmt.Errorf("missing the %q animation", required)
+	}
+
+	s.Image = sheet
+	s.Width, s.Height = def.FrameWidth, def.FrameHeight
+	s.AnchorX, s.AnchorY = 0.5, 0.5
+	if def.AnchorX != nil {
+		s.AnchorX = *def.AnchorX
+	}
+	if def.AnchorY != nil {
+		s.AnchorY = *def.AnchorY
+	}
+	s.Rotate = def.Rotate
+	s.Animations = animations
+	s.FrameCount = len(animations[required].Frames)
+	s.AnimSpeed = animations[required].FrameTime
+	return nil
+}
+
+// frame returns a frame of an animation, or nil when the sprite has no sheet for it
+func (s *Sprite) frame(name string, index int) *ebiten.Image {
+	if s == nil {
+		return nil
+	}
+	anim, ok := s.Animations[name]
+	if !ok {
+		return nil
+	}
+	return anim.Frames[index%len(anim.Frames)]
+}
+
+// drawFrame draws a frame with the sprite's anchor at x, y, turned by angle if the sheet rotates
+func (s *Sprite) drawFrame(screen *ebiten.Image, frame *ebiten.Image, x, y float32, angle float64, op *ebiten.DrawImageOptions) {
+	op.GeoM.Translate(-s.AnchorX*float64(s.Width), -s.AnchorY*float64(s.Height))
+	if s.Rotate {
+		op.GeoM.Rotate(angle)
+	}
+	op.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(frame, op)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+	"testing/fstest"
+)
+
+// testSheetFS holds a blank 120x80 sheet.png: three 40x40 columns on two rows
+func testSheetFS(t *testing.T) fstest.MapFS {
+	t.Helper()
+	var data bytes.Buffer
+	if err := png.Encode(&data, image.NewRGBA(image.Rect(0, 0, 120, 80))); err != nil {
+		t.Fatal(err)
+	}
+	return fstest.MapFS{"sheet.png": {Data: data.Bytes()}}
+}
+
+func TestLoadSheetSlicesFrames(t *testing.T) {
+	def := SpriteSheetDef{
+		Image:       "sheet.png",
+		FrameWidth:  40,
+		FrameHeight: 40,
+		Animations: map[string]AnimationDef{
+			"idle": {Row: 0, Frames: 2, FrameTime: 0.5},
+			"fire": {Row: 1, Start: 1, Frames: 2, FrameTime: 0.05},
+		},
+	}
+	var sprite Sprite
+	if err := sprite.loadSheet(testSheetFS(t), def, towerAnimation); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]image.Rectangle{
+		"idle": {image.Rect(0, 0, 40, 40), image.Rect(40, 0, 80, 40)},
+		"fire": {image.Rect(40, 40, 80, 80), image.Rect(80, 40, 120, 80)},
+	}
+	for name, rects := range want {
+		anim := sprite.Animations[name]
+		if anim == nil || len(anim.Frames) != len(rects) {
+			t.Fatalf("%s: got %v, want %d frames", name, anim, len(rects))
+		}
+		for i, rect := range rects {
+			if got := anim.Frames[i].Bounds(); got != rect {
+				t.Errorf("%s frame %d: got %v, want %v", name, i, got, rect)
+			}
+		}
+	}
+	if sprite.FrameCount != 2 || sprite.AnimSpeed != 0.5 {
+		t.Errorf("idle drives the sprite: got %d frames at %gs, want 2 at 0.5s", sprite.FrameCount, sprite.AnimSpeed)
+	}
+	if sprite.AnchorX != 0.5 || sprite.AnchorY != 0.5 {
+		t.Errorf("default anchor: got %g, %g, want the center", sprite.AnchorX, sprite.AnchorY)
+	}
+}
+
+func TestLoadSheetRejectsInvalidAnimations(t *testing.T) {
+	tests := map[string]map[string]AnimationDef{
+		"no frames":            {"idle": {Frames: 0, FrameTime: 0.1}},
+		"negative frames":      {"idle": {Frames: -2, FrameTime: 0.1}},
+		"no frame time":        {"idle": {Frames: 2, FrameTime: 0}},
+		"negative frame time":  {"idle": {Frames: 2, FrameTime: -0.1}},
+		"below the sheet":      {"idle": {Row: 2, Frames: 1, FrameTime: 0.1}},
+		"past the last column": {"idle": {Start: 2, Frames: 2, FrameTime: 0.1}},
+		"no idle animation":    {"fire": {Frames: 2, FrameTime: 0.1}},
+	}
+	for name, animations := range tests {
+		def := SpriteSheetDef{Image: "sheet.png", FrameWidth: 40, FrameHeight: 40, Animations: animations}
+		var sprite Sprite
+		if err := sprite.loadSheet(testSheetFS(t), def, towerAnimation); err == nil {
+			t.Errorf("%s: loaded without an error", name)
+		}
+		if sprite.Image != nil || sprite.Animations != nil {
+			t.Errorf("%s: the sprite was changed", name)
+		}
+	}
+}
+
+func TestLoadSpritesExample(t *testing.T) {
+	gm := NewGraphicsManager(DefaultTheme(), 0)
+	if err := gm.LoadSprites("examples/sprites"); err != nil {
+		t.Fatal(err)
+	}
+
+	sprite := gm.TowerSprites[TowerBasic]
+	for name, frames := range map[string]int{towerAnimation: 2, fireAnimation: 3} {
+		if anim := sprite.Animations[name]; anim == nil || len(anim.Frames) != frames {
+			t.Errorf("%s: got %v, want %d frames", name, anim, frames)
+		}
+	}
+	if !sprite.Rotate {
+		t.Error("the example sheet turns toward targets")
+	}
+	if gm.TowerSprites[TowerHeavy].Animations != nil {
+		t.Error("towers without a sheet keep their vector graphics")
+	}
+}
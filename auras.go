@@ -0,0 +1,111 @@
+package main
+
+import "math"
+
+// AuraStat identifies the tower statistic an aura modifies
+type AuraStat int
+
+const (
+	AuraRange AuraStat = iota
+	AuraFireRate
+	AuraDamage
+	AuraCritChance
+)
+
+// Aura is a buff a tower applies to every other tower within Radius
+type Aura struct {
+	Stat   AuraStat
+	Amount float64 // Fractional bonus (0.2 = +20%); for crit chance, the added probability
+	Radius float64
+}
+
+// GetTowerAuras returns the auras emitted by a tower type
+func (c *GameConfig) GetTowerAuras(towerType int) []Aura {
+	var auras []Aura
+
+	switch towerType {
+	case TowerSniper: // Spots targets for nearby towers
+		if c.SniperAuraCritChance > 0 {
+			auras = append(auras, Aura{Stat: AuraCritChance, Amount: c.SniperAuraCritChance, Radius: c.SniperAuraRadius})
+		}
+	case TowerLaser: // Overcharges nearby towers
+		if c.LaserAuraFireRateBonus > 0 {
+			auras = append(auras, Aura{Stat: AuraFireRate, Amount: c.LaserAuraFireRateBonus, Radius: c.LaserAuraRadius})
+		}
+	case TowerRadar:
+		auras = append(auras, Aura{Stat: AuraRange, Amount: c.RadarRangeBonus, Radius: c.RadarRadius})
+	case TowerArmory: // Adjacent cells only
+		auras = append(auras, Aura{Stat: AuraDamage, Amount: c.ArmoryDamageBonus, Radius: float64(c.GridSize) * 1.5})
+	}
+
+	return auras
+}
+
+// LocaleKey returns the localization key of a short label for the aura's stat
+func (s AuraStat) LocaleKey() string {
+	switch s {
+	case AuraFireRate:
+		return "aura.fire_rate"
+	case AuraDamage:
+		return "aura.damage"
+	case AuraCritChance:
+		return "aura.crit"
+	default:
+		return "aura.range"
+	}
+}
+
+// describeAura formats an aura for the tower tooltip and info panel
+func (g *Game) describeAura(a Aura) string {
+	return g.loc.T("aura.describe", a.Amount*100, g.loc.T(a.Stat.LocaleKey()), a.Radius)
+}
+
+// recalculateAuras rebuilds every tower's effective stats from its base stats and the
+// auras of the towers around it; call it whenever g.towers changes
+func (g *Game) recalculateAuras() {
+	for _, tower := range g.towers {
+		tower.RangeBonus = 0
+		tower.FireRateBonus = 0
+		tower.DamageBonus = 0
+		tower.CritChance = 0
+
+		for _, source := range g.towers {
+			if source == tower {
+				continue // Auras only affect other towers
+			}
+
+			dx := tower.Position.X - source.Position.X
+			dy := tower.Position.Y - source.Position.Y
+			distance := math.Sqrt(dx*dx + dy*dy)
+
+			for _, aura := range source.Auras {
+				if distance > aura.Radius {
+					continue
+				}
+
+				switch aura.Stat {
+				case AuraRange:
+					tower.RangeBonus += aura.Amount
+				case AuraFireRate:
+					tower.FireRateBonus += aura.Amount
+				case AuraDamage:
+					tower.DamageBonus += aura.Amount
+				case AuraCritChance:
+					tower.CritChance += aura.Amount
+				}
+			}
+		}
+
+		tower.CritChance = math.Min(tower.CritChance, 1.0)
+
+		// Support structures keep their own effect radius
+		if g.config.IsSupportStructure(tower.Type) {
+			continue
+		}
+
+		tower.Range = tower.BaseRange * (1 + tower.RangeBonus)
+		tower.Damage = int(float64(tower.BaseDamage) * (1 + tower.DamageBonus))
+		// FireRate is the cooldown between shots, so a faster rate shortens it
+		tower.FireRate = tower.BaseFireRate / (1 + tower.FireRateBonus)
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Each wheel notch zooms by this factor
+const cameraWheelZoomStep = 1.1
+
+// Camera maps the world (the map in pixels) onto the screen; X and Y are the world point
+// shown at the center of the view
+type Camera struct {
+	X, Y float64
+	Zoom float64 // Player zoom; the screen's pixel scale is applied on top
+
+	viewWidth, viewHeight   float64 // Screen size in pixels
+	pixelScale              float64
+	worldWidth, worldHeight float64
+	minZoom, maxZoom        float64
+
+	dragging     bool // The middle mouse button is dragging the view
+	dragX, dragY int  // Cursor position at the last drag step
+}
+
+// NewCamera creates a camera showing the center of the map at normal zoom
+func NewCamera(config *GameConfig) *Camera {
+	mapWidth, mapHeight := config.MapSize()
+	c := &Camera{
+		viewWidth:   float64(config.WindowWidth),
+		viewHeight:  float64(config.WindowHeight),
+		pixelScale:  1,
+		worldWidth:  float64(mapWidth * config.GridSize),
+		worldHeight: float64(mapHeight * config.GridSize),
+		minZoom:     config.CameraMinZoom,
+		maxZoom:     config.CameraMaxZoom,
+	}
+	c.Reset()
+	return c
+}
+
+// Reset centers the map at normal zoom
+func (c *Camera) Reset() {
+	c.X = c.worldWidth / 2
+	c.Y = c.worldHeight / 2
+	c.Zoom = 1
+	c.dragging = false
+	c.clamp()
+}
+
+// SetView updates the screen size and pixel scale after the window was resized
+func (c *Camera) SetView(width, height int, pixelScale float64) {
+	c.viewWidth, c.viewHeight = float64(width), float64(height)
+	c.pixelScale = pixelScale
+	c.clamp()
+}
+
+// Scale returns the number of screen pixels per world pixel
+func (c *Camera) Scale() float64 {
+	return c.Zoom * c.pixelScale
+}
+
+// WorldToScreen converts a world position to screen pixels
+func (c *Camera) WorldToScreen(x, y float64) (float64, float64) {
+	scale := c.Scale()
+	return (x-c.X)*scale + c.viewWidth/2, (y-c.Y)*scale + c.viewHeight/2
+}
+
+// ScreenToWorld converts screen pixels to a world position
+func (c *Camera) ScreenToWorld(x, y float64) (float64, float64) {
+	scale := c.Scale()
+	return (x-c.viewWidth/2)/scale + c.X, (y-c.viewHeight/2)/scale + c.Y
+}
+
+// GeoM returns the transform that draws the world onto the screen
+func (c *Camera) GeoM() ebiten.GeoM {
+	var geoM ebiten.GeoM
+	geoM.Translate(-c.X, -c.Y)
+	geoM.Scale(c.Scale(), c.Scale())
+	geoM.Translate(c.viewWidth/2, c.viewHeight/2)
+	return geoM
+}
+
+// Pan moves the view by a distance in screen pixels
+func (c *Camera) Pan(dx, dy float64) {
+	c.X += dx / c.Scale()
+	c.Y += dy / c.Scale()
+	c.clamp()
+}
+
+// ZoomAt multiplies the zoom, keeping the world point under a screen position in place
+func (c *Camera) ZoomAt(factor, screenX, screenY float64) {
+	worldX, worldY := c.ScreenToWorld(screenX, screenY)
+	c.Zoom = math.Max(c.minZoom, math.Min(c.Zoom*factor, c.maxZoom))
+	c.X = worldX - (screenX-c.viewWidth/2)/c.Scale()
+	c.Y = worldY - (screenY-c.viewHeight/2)/c.Scale()
+	c.clamp()
+}
+
+// Follow pans just enough to keep a world position at least margin screen pixels inside the view
+func (c *Camera) Follow(x, y, margin float64) {
+	screenX, screenY := c.WorldToScreen(x, y)
+	dx, dy := 0.0, 0.0
+	if screenX < margin {
+		dx = screenX - margin
+	} else if screenX > c.viewWidth-margin {
+		dx = screenX - (c.viewWidth - margin)
+	}
+	if screenY < margin {
+		dy = screenY - margin
+	} else if screenY > c.viewHeight-margin {
+		dy = screenY - (c.viewHeight - margin)
+	}
+	if dx != 0 || dy != 0 {
+		c.Pan(dx, dy)
+	}
+}
+
+// clamp keeps the map filling the view, or centers it along an axis where it is smaller
+func (c *Camera) clamp() {
+	c.X = clampAxis(c.X, c.worldWidth, c.viewWidth/c.Scale())
+	c.Y = clampAxis(c.Y, c.worldHeight, c.viewHeight/c.Scale())
+}
+
+// clampAxis limits the view center along one axis to the range that keeps the view on the map
+func clampAxis(center, world, view float64) float64 {
+	if view >= world {
+		return world / 2
+	}
+	return math.Max(view/2, math.Min(center, world-view/2))
+}
+
+// handleCameraInput pans and zooms the camera from the keyboard, the right stick, the mouse
+// wheel, middle-button drags and the window edges; it runs while playing and while paused
+func (g *Game) handleCameraInput() {
+	cam := g.camera
+	if g.input.JustPressed(ActionCameraReset) {
+		cam.Reset()
+	}
+
+	// Keys and the right stick pan at a fixed speed
+	step := g.config.CameraPanSpeed * g.pixelScale / 60.0
+	dx, dy := 0.0, 0.0
+	if g.input.Held(ActionCameraLeft) {
+		dx -= step
+	}
+	if g.input.Held(ActionCameraRight) {
+		dx += step
+	}
+	if g.input.Held(ActionCameraUp) {
+		dy -= step
+	}
+	if g.input.Held(ActionCameraDown) {
+		dy += step
+	}
+
+	// Edge panning only while the cursor is inside a focused window
+	x, y := g.ui.MouseX, g.ui.MouseY
+	margin := int(float64(g.config.CameraEdgeMargin) * g.pixelScale)
+	width, height := g.screenWidth, g.screenHeight
+	if margin > 0 && ebiten.IsFocused() && !cam.dragging && x >= 0 && y >= 0 && x < width && y < height {
+		if x < margin {
+			dx -= step
+		} else if x >= width-margin {
+			dx += step
+		}
+		if y < margin {
+			dy -= step
+		} else if y >= height-margin {
+			dy += step
+		}
+	}
+	if dx != 0 || dy != 0 {
+		cam.Pan(dx, dy)
+	}
+
+	// Middle-button drag moves the map with the cursor
+	if g.input.MouseJustPressed(ebiten.MouseButtonMiddle) && g.ui.Hovered == nil {
+		cam.dragging = true
+		cam.dragX, cam.dragY = x, y
+	}
+	if cam.dragging {
+		if !g.input.MouseHeld(ebiten.MouseButtonMiddle) {
+			cam.dragging = false
+		} else {
+			cam.Pan(float64(cam.dragX-x), float64(cam.dragY-y))
+			cam.dragX, cam.dragY = x, y
+		}
+	}
+
+	// The wheel zooms around the cursor unless it is over a widget
+	if _, wheelY := ebiten.Wheel(); wheelY != 0 && g.ui.Hovered == nil {
+		cam.ZoomAt(math.Pow(cameraWheelZoomStep, wheelY), float64(x), float64(y))
+	}
+}
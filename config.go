@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"math"
 	"os"
 )
 
@@ -13,6 +14,17 @@ type GameConfig struct {
 	WindowTitle  string `json:"window_title"`
 	Fullscreen   bool   `json:"fullscreen"`
 	VSync        bool   `json:"vsync"`
+	Resizable    bool   `json:"resizable"`
+	ScaleMode    string `json:"scale_mode"` // "expand" shows more of the map in a bigger window, "letterbox" scales the window size with bars
+	HiDPI        bool   `json:"hidpi"`      // Render at the monitor's full pixel density
+
+	// Map and camera settings
+	MapWidth         int     `json:"map_width"`          // Map size in grid cells; 0 fits the window
+	MapHeight        int     `json:"map_height"`         // Map size in grid cells; 0 fits the window
+	CameraPanSpeed   float64 `json:"camera_pan_speed"`   // Screen pixels per second for keys, stick and edge panning
+	CameraEdgeMargin int     `json:"camera_edge_margin"` // Pixels from the window edge that pan the camera; 0 disables
+	CameraMinZoom    float64 `json:"camera_min_zoom"`
+	CameraMaxZoom    float64 `json:"camera_max_zoom"`
 
 	// Gameplay settings
 	StartingMoney int     `json:"starting_money"`
@@ -31,15 +43,19 @@ type GameConfig struct {
 	HeavyTowerRange  float64 `json:"heavy_tower_range"`
 	HeavyTowerRate   float64 `json:"heavy_tower_fire_rate"`
 
-	SniperTowerCost   int     `json:"sniper_tower_cost"`
-	SniperTowerDamage int     `json:"sniper_tower_damage"`
-	SniperTowerRange  float64 `json:"sniper_tower_range"`
-	SniperTowerRate   float64 `json:"sniper_tower_fire_rate"`
+	SniperTowerCost      int     `json:"sniper_tower_cost"`
+	SniperTowerDamage    int     `json:"sniper_tower_damage"`
+	SniperTowerRange     float64 `json:"sniper_tower_range"`
+	SniperTowerRate      float64 `json:"sniper_tower_fire_rate"`
+	SniperAuraCritChance float64 `json:"sniper_aura_crit_chance"` // Crit chance granted to nearby towers
+	SniperAuraRadius     float64 `json:"sniper_aura_radius"`
 
-	LaserTowerCost   int     `json:"laser_tower_cost"`
-	LaserTowerDamage int     `json:"laser_tower_damage"`
-	LaserTowerRange  float64 `json:"laser_tower_range"`
-	LaserTowerRate   float64 `json:"laser_tower_fire_rate"`
+	LaserTowerCost         int     `json:"laser_tower_cost"`
+	LaserTowerDamage       int     `json:"laser_tower_damage"`
+	LaserTowerRange        float64 `json:"laser_tower_range"`
+	LaserTowerRate         float64 `json:"laser_tower_fire_rate"`
+	LaserAuraFireRateBonus float64 `json:"laser_aura_fire_rate_bonus"` // Fractional fire rate increase for nearby towers
+	LaserAuraRadius        float64 `json:"laser_aura_radius"`
 
 	SplashTowerCost   int     `json:"splash_tower_cost"`
 	SplashTowerDamage int     `json:"splash_tower_damage"`
@@ -54,6 +70,32 @@ type GameConfig struct {
 	SlowEffect      float64 `json:"slow_effect"`
 	SlowDuration    float64 `json:"slow_duration"`
 
+	// Critical hits (chance comes from auras)
+	CritMultiplier float64 `json:"crit_multiplier"`
+
+	// Turret settings: how fast barrels turn toward their target, in degrees per second
+	BasicTowerTurnRate  float64 `json:"basic_tower_turn_rate"`
+	HeavyTowerTurnRate  float64 `json:"heavy_tower_turn_rate"`
+	SniperTowerTurnRate float64 `json:"sniper_tower_turn_rate"`
+	RequireFacing       bool    `json:"require_facing"` // Turrets only fire once their barrel points at the target
+	AimTolerance        float64 `json:"aim_tolerance"`  // Degrees off target that still count as facing it
+
+	// Upgrade and selling settings
+	MaxTowerLevel      int     `json:"max_tower_level"`
+	UpgradeCostFactor  float64 `json:"upgrade_cost_factor"`  // Upgrade cost as a fraction of tower cost, per current level
+	UpgradeDamageBonus float64 `json:"upgrade_damage_bonus"` // Fractional damage increase per level
+	UpgradeRangeBonus  float64 `json:"upgrade_range_bonus"`  // Fractional range increase per level
+	SellRefundRate     float64 `json:"sell_refund_rate"`     // Fraction of money spent returned when selling
+
+	// Support structure settings
+	BankCost          int     `json:"bank_cost"`
+	BankIncome        int     `json:"bank_income"` // Money paid by each bank at every wave transition
+	RadarCost         int     `json:"radar_cost"`
+	RadarRadius       float64 `json:"radar_radius"`
+	RadarRangeBonus   float64 `json:"radar_range_bonus"` // Fractional range increase for towers within the radar radius
+	ArmoryCost        int     `json:"armory_cost"`
+	ArmoryDamageBonus float64 `json:"armory_damage_bonus"` // Fractional damage increase for adjacent towers
+
 	// Enemy settings
 	BaseEnemyHealth int `json:"base_enemy_health"`
 	HealthPerWave   int `json:"health_per_wave"`
@@ -61,12 +103,23 @@ type GameConfig struct {
 	WaveBonus       int `json:"wave_bonus"`
 	EnemiesPerWave  int `json:"enemies_per_wave"`
 
+	// Economy settings
+	InterestEnabled bool    `json:"interest_enabled"`
+	InterestRate    float64 `json:"interest_rate"` // Percent of unspent money paid each wave
+	InterestCap     int     `json:"interest_cap"`  // Maximum interest paid per wave
+
 	// Visual settings
 	ShowRange       bool    `json:"show_range"`
 	ShowHealthBars  bool    `json:"show_health_bars"`
 	ShowFPS         bool    `json:"show_fps"`
 	GridSize        int     `json:"grid_size"`
 	ParticleDensity float64 `json:"particle_density"`
+	MaxParticles    int     `json:"max_particles"`  // Particle budget; new particles are dropped while it is used up
+	UIScale         float64 `json:"ui_scale"`       // Multiplier for UI text and widget sizes
+	Language        string  `json:"language"`       // String table from locales/, e.g. "en" or "de"
+	AssetsDir       string  `json:"assets_dir"`     // Sprite sheets and emitters here replace the built-in ones from assets/; "" uses only the built-in ones
+	Theme           string  `json:"theme"`          // Color palette from themes/, e.g. "dark", or a path to a theme .json file
+	ScreenshotDir   string  `json:"screenshot_dir"` // Screenshots are saved here as PNG files; "" saves them in the working directory
 
 	// Audio settings (for future use)
 	MasterVolume float64 `json:"master_volume"`
@@ -74,15 +127,64 @@ type GameConfig struct {
 	MusicVolume  float64 `json:"music_volume"`
 	MuteAudio    bool    `json:"mute_audio"`
 
-	// Controls
-	PauseKey        string `json:"pause_key"`
-	RestartKey      string `json:"restart_key"`
-	TowerSelect1Key string `json:"tower_select_1_key"`
-	TowerSelect2Key string `json:"tower_select_2_key"`
-	TowerSelect3Key string `json:"tower_select_3_key"`
-	TowerSelect4Key string `json:"tower_select_4_key"`
-	TowerSelect5Key string `json:"tower_select_5_key"`
-	TowerSelect6Key string `json:"tower_select_6_key"`
+	// Controls: comma-separated key names, e.g. "Escape,P" (see input.go)
+	PauseKey             string `json:"pause_key"`
+	RestartKey           string `json:"restart_key"`
+	MenuKey              string `json:"menu_key"`
+	MenuUpKey            string `json:"menu_up_key"`
+	MenuDownKey          string `json:"menu_down_key"`
+	ConfirmKey           string `json:"confirm_key"`
+	NextWaveKey          string `json:"next_wave_key"`
+	SpeedUpKey           string `json:"speed_up_key"`
+	SpeedDownKey         string `json:"speed_down_key"`
+	FrameStepKey         string `json:"frame_step_key"`
+	UpgradeKey           string `json:"upgrade_key"`
+	SellKey              string `json:"sell_key"`
+	TargetingKey         string `json:"targeting_key"`
+	TowerSelect1Key      string `json:"tower_select_1_key"`
+	TowerSelect2Key      string `json:"tower_select_2_key"`
+	TowerSelect3Key      string `json:"tower_select_3_key"`
+	TowerSelect4Key      string `json:"tower_select_4_key"`
+	TowerSelect5Key      string `json:"tower_select_5_key"`
+	TowerSelect6Key      string `json:"tower_select_6_key"`
+	TowerSelect7Key      string `json:"tower_select_7_key"`
+	TowerSelect8Key      string `json:"tower_select_8_key"`
+	TowerSelect9Key      string `json:"tower_select_9_key"`
+	SandboxSpawnKey      string `json:"sandbox_spawn_key"`
+	SandboxHealthUpKey   string `json:"sandbox_health_up_key"`
+	SandboxHealthDownKey string `json:"sandbox_health_down_key"`
+	SandboxSpeedUpKey    string `json:"sandbox_speed_up_key"`
+	SandboxSpeedDownKey  string `json:"sandbox_speed_down_key"`
+	SandboxClearKey      string `json:"sandbox_clear_key"`
+	SandboxLargeStepKey  string `json:"sandbox_large_step_key"`
+	CameraUpKey          string `json:"camera_up_key"`
+	CameraDownKey        string `json:"camera_down_key"`
+	CameraLeftKey        string `json:"camera_left_key"`
+	CameraRightKey       string `json:"camera_right_key"`
+	CameraResetKey       string `json:"camera_reset_key"`
+	ScreenshotKey        string `json:"screenshot_key"`
+
+	// Gamepad: comma-separated standard layout button names, e.g. "A" or "DpadUp" (see input.go)
+	GamepadEnabled           bool    `json:"gamepad_enabled"`
+	GamepadDeadzone          float64 `json:"gamepad_deadzone"`      // Stick deflection (0-1) ignored as noise
+	GamepadCursorRepeat      float64 `json:"gamepad_cursor_repeat"` // Seconds between cursor steps while held
+	GamepadPauseButton       string  `json:"gamepad_pause_button"`
+	GamepadRestartButton     string  `json:"gamepad_restart_button"`
+	GamepadMenuButton        string  `json:"gamepad_menu_button"`
+	GamepadMenuUpButton      string  `json:"gamepad_menu_up_button"`
+	GamepadMenuDownButton    string  `json:"gamepad_menu_down_button"`
+	GamepadConfirmButton     string  `json:"gamepad_confirm_button"`
+	GamepadNextWaveButton    string  `json:"gamepad_next_wave_button"`
+	GamepadUpgradeButton     string  `json:"gamepad_upgrade_button"`
+	GamepadSellButton        string  `json:"gamepad_sell_button"`
+	GamepadTargetingButton   string  `json:"gamepad_targeting_button"`
+	GamepadPlaceButton       string  `json:"gamepad_place_button"`
+	GamepadPrevTowerButton   string  `json:"gamepad_prev_tower_button"`
+	GamepadNextTowerButton   string  `json:"gamepad_next_tower_button"`
+	GamepadCursorUpButton    string  `json:"gamepad_cursor_up_button"`
+	GamepadCursorDownButton  string  `json:"gamepad_cursor_down_button"`
+	GamepadCursorLeftButton  string  `json:"gamepad_cursor_left_button"`
+	GamepadCursorRightButton string  `json:"gamepad_cursor_right_button"`
 
 	// Debug settings
 	DebugMode      bool `json:"debug_mode"`
@@ -100,6 +202,17 @@ func DefaultConfig() *GameConfig {
 		WindowTitle:  "Tower Defense",
 		Fullscreen:   false,
 		VSync:        true,
+		Resizable:    true,
+		ScaleMode:    scaleModeExpand,
+		HiDPI:        true,
+
+		// Map and camera settings
+		MapWidth:         0,
+		MapHeight:        0,
+		CameraPanSpeed:   600,
+		CameraEdgeMargin: 12,
+		CameraMinZoom:    0.5,
+		CameraMaxZoom:    2.0,
 
 		// Gameplay settings
 		StartingMoney: 100,
@@ -118,15 +231,19 @@ func DefaultConfig() *GameConfig {
 		HeavyTowerRange:  60,
 		HeavyTowerRate:   0.5,
 
-		SniperTowerCost:   150,
-		SniperTowerDamage: 100,
-		SniperTowerRange:  150,
-		SniperTowerRate:   0.3,
+		SniperTowerCost:      150,
+		SniperTowerDamage:    100,
+		SniperTowerRange:     150,
+		SniperTowerRate:      0.3,
+		SniperAuraCritChance: 0.1,
+		SniperAuraRadius:     80,
 
-		LaserTowerCost:   200,
-		LaserTowerDamage: 15,
-		LaserTowerRange:  70,
-		LaserTowerRate:   3.0,
+		LaserTowerCost:         200,
+		LaserTowerDamage:       15,
+		LaserTowerRange:        70,
+		LaserTowerRate:         3.0,
+		LaserAuraFireRateBonus: 0.1,
+		LaserAuraRadius:        60,
 
 		SplashTowerCost:   180,
 		SplashTowerDamage: 40,
@@ -141,6 +258,29 @@ func DefaultConfig() *GameConfig {
 		SlowEffect:      0.5,
 		SlowDuration:    2.0,
 
+		CritMultiplier: 2.0,
+
+		BasicTowerTurnRate:  360,
+		HeavyTowerTurnRate:  180,
+		SniperTowerTurnRate: 120,
+		RequireFacing:       false,
+		AimTolerance:        10,
+
+		MaxTowerLevel:      3,
+		UpgradeCostFactor:  0.75,
+		UpgradeDamageBonus: 0.5,
+		UpgradeRangeBonus:  0.1,
+		SellRefundRate:     0.7,
+
+		// Support structure settings
+		BankCost:          150,
+		BankIncome:        30,
+		RadarCost:         125,
+		RadarRadius:       100,
+		RadarRangeBonus:   0.2,
+		ArmoryCost:        175,
+		ArmoryDamageBonus: 0.25,
+
 		// Enemy settings
 		BaseEnemyHealth: 50,
 		HealthPerWave:   10,
@@ -148,12 +288,23 @@ func DefaultConfig() *GameConfig {
 		WaveBonus:       50,
 		EnemiesPerWave:  3,
 
+		// Economy settings
+		InterestEnabled: false,
+		InterestRate:    5,
+		InterestCap:     50,
+
 		// Visual settings
 		ShowRange:       true,
 		ShowHealthBars:  true,
 		ShowFPS:         false,
 		GridSize:        40,
 		ParticleDensity: 1.0,
+		MaxParticles:    2000,
+		UIScale:         1.0,
+		Language:        "en",
+		AssetsDir:       "mods",
+		Theme:           defaultTheme,
+		ScreenshotDir:   "screenshots",
 
 		// Audio settings
 		MasterVolume: 1.0,
@@ -162,14 +313,63 @@ func DefaultConfig() *GameConfig {
 		MuteAudio:    false,
 
 		// Controls
-		PauseKey:        "Space",
-		RestartKey:      "R",
-		TowerSelect1Key: "1",
-		TowerSelect2Key: "2",
-		TowerSelect3Key: "3",
-		TowerSelect4Key: "4",
-		TowerSelect5Key: "5",
-		TowerSelect6Key: "6",
+		PauseKey:             "Escape,P",
+		RestartKey:           "R",
+		MenuKey:              "M",
+		MenuUpKey:            "ArrowUp,W",
+		MenuDownKey:          "ArrowDown,S",
+		ConfirmKey:           "Enter,Space",
+		NextWaveKey:          "Space",
+		SpeedUpKey:           "Equal,NumpadAdd",
+		SpeedDownKey:         "Minus,NumpadSubtract",
+		FrameStepKey:         "Period",
+		UpgradeKey:           "U",
+		SellKey:              "X",
+		TargetingKey:         "T",
+		TowerSelect1Key:      "1",
+		TowerSelect2Key:      "2",
+		TowerSelect3Key:      "3",
+		TowerSelect4Key:      "4",
+		TowerSelect5Key:      "5",
+		TowerSelect6Key:      "6",
+		TowerSelect7Key:      "7",
+		TowerSelect8Key:      "8",
+		TowerSelect9Key:      "9",
+		SandboxSpawnKey:      "E",
+		SandboxHealthUpKey:   "BracketRight",
+		SandboxHealthDownKey: "BracketLeft",
+		SandboxSpeedUpKey:    "Quote",
+		SandboxSpeedDownKey:  "Semicolon",
+		SandboxClearKey:      "C",
+		SandboxLargeStepKey:  "Shift",
+		CameraUpKey:          "W,ArrowUp",
+		CameraDownKey:        "S,ArrowDown",
+		CameraLeftKey:        "A,ArrowLeft",
+		CameraRightKey:       "D,ArrowRight",
+		CameraResetKey:       "Home",
+		ScreenshotKey:        "F12",
+
+		// Gamepad settings
+		GamepadEnabled:           true,
+		GamepadDeadzone:          0.5,
+		GamepadCursorRepeat:      0.12,
+		GamepadPauseButton:       "Start",
+		GamepadRestartButton:     "Y",
+		GamepadMenuButton:        "Back",
+		GamepadMenuUpButton:      "DpadUp",
+		GamepadMenuDownButton:    "DpadDown",
+		GamepadConfirmButton:     "A",
+		GamepadNextWaveButton:    "RT",
+		GamepadUpgradeButton:     "Y",
+		GamepadSellButton:        "X",
+		GamepadTargetingButton:   "LT",
+		GamepadPlaceButton:       "A",
+		GamepadPrevTowerButton:   "LB",
+		GamepadNextTowerButton:   "RB",
+		GamepadCursorUpButton:    "DpadUp",
+		GamepadCursorDownButton:  "DpadDown",
+		GamepadCursorLeftButton:  "DpadLeft",
+		GamepadCursorRightButton: "DpadRight",
 
 		// Debug settings
 		DebugMode:      false,
@@ -225,11 +425,55 @@ func (c *GameConfig) ValidateConfig() {
 	if c.WindowHeight < 480 {
 		c.WindowHeight = 480
 	}
-	if c.WindowWidth > 1920 {
-		c.WindowWidth = 1920
+	if c.WindowWidth > 3840 {
+		c.WindowWidth = 3840
+	}
+	if c.WindowHeight > 2160 {
+		c.WindowHeight = 2160
+	}
+	if c.ScaleMode != scaleModeExpand && c.ScaleMode != scaleModeLetterbox {
+		c.ScaleMode = scaleModeExpand
+	}
+
+	// Clamp map and camera values
+	if c.MapWidth < 0 {
+		c.MapWidth = 0
+	}
+	if c.MapWidth > 0 && c.MapWidth < 8 {
+		c.MapWidth = 8
+	}
+	if c.MapWidth > 200 {
+		c.MapWidth = 200
+	}
+	if c.MapHeight < 0 {
+		c.MapHeight = 0
+	}
+	if c.MapHeight > 0 && c.MapHeight < 8 {
+		c.MapHeight = 8
+	}
+	if c.MapHeight > 200 {
+		c.MapHeight = 200
+	}
+	if c.CameraPanSpeed < 50 {
+		c.CameraPanSpeed = 50
+	}
+	if c.CameraEdgeMargin < 0 {
+		c.CameraEdgeMargin = 0
+	}
+	if c.CameraEdgeMargin > 100 {
+		c.CameraEdgeMargin = 100
+	}
+	if c.CameraMinZoom < 0.25 {
+		c.CameraMinZoom = 0.25
+	}
+	if c.CameraMinZoom > 1 {
+		c.CameraMinZoom = 1
+	}
+	if c.CameraMaxZoom < 1 {
+		c.CameraMaxZoom = 1
 	}
-	if c.WindowHeight > 1080 {
-		c.WindowHeight = 1080
+	if c.CameraMaxZoom > 4 {
+		c.CameraMaxZoom = 4
 	}
 
 	// Clamp gameplay values
@@ -273,6 +517,84 @@ func (c *GameConfig) ValidateConfig() {
 		c.HeavyTowerRate = 0.1
 	}
 
+	// Clamp aura values
+	if c.SniperAuraCritChance < 0 {
+		c.SniperAuraCritChance = 0
+	}
+	if c.SniperAuraCritChance > 1 {
+		c.SniperAuraCritChance = 1
+	}
+	if c.SniperAuraRadius < 0 {
+		c.SniperAuraRadius = 0
+	}
+	if c.LaserAuraFireRateBonus < 0 {
+		c.LaserAuraFireRateBonus = 0
+	}
+	if c.LaserAuraRadius < 0 {
+		c.LaserAuraRadius = 0
+	}
+	if c.CritMultiplier < 1 {
+		c.CritMultiplier = 1
+	}
+	if c.BasicTowerTurnRate < 10 {
+		c.BasicTowerTurnRate = 10
+	}
+	if c.HeavyTowerTurnRate < 10 {
+		c.HeavyTowerTurnRate = 10
+	}
+	if c.SniperTowerTurnRate < 10 {
+		c.SniperTowerTurnRate = 10
+	}
+	if c.AimTolerance < 1 {
+		c.AimTolerance = 1
+	}
+	if c.AimTolerance > 180 {
+		c.AimTolerance = 180
+	}
+
+	// Clamp upgrade values
+	if c.MaxTowerLevel < 1 {
+		c.MaxTowerLevel = 1
+	}
+	if c.UpgradeCostFactor < 0 {
+		c.UpgradeCostFactor = 0
+	}
+	if c.UpgradeDamageBonus < 0 {
+		c.UpgradeDamageBonus = 0
+	}
+	if c.UpgradeRangeBonus < 0 {
+		c.UpgradeRangeBonus = 0
+	}
+	if c.SellRefundRate < 0 {
+		c.SellRefundRate = 0
+	}
+	if c.SellRefundRate > 1 {
+		c.SellRefundRate = 1
+	}
+
+	// Clamp support structure values
+	if c.BankCost < 1 {
+		c.BankCost = 1
+	}
+	if c.BankIncome < 0 {
+		c.BankIncome = 0
+	}
+	if c.RadarCost < 1 {
+		c.RadarCost = 1
+	}
+	if c.RadarRadius < 10 {
+		c.RadarRadius = 10
+	}
+	if c.RadarRangeBonus < 0 {
+		c.RadarRangeBonus = 0
+	}
+	if c.ArmoryCost < 1 {
+		c.ArmoryCost = 1
+	}
+	if c.ArmoryDamageBonus < 0 {
+		c.ArmoryDamageBonus = 0
+	}
+
 	// Clamp enemy values
 	if c.BaseEnemyHealth < 1 {
 		c.BaseEnemyHealth = 1
@@ -290,6 +612,17 @@ func (c *GameConfig) ValidateConfig() {
 		c.EnemiesPerWave = 1
 	}
 
+	// Clamp economy values
+	if c.InterestRate < 0 {
+		c.InterestRate = 0
+	}
+	if c.InterestRate > 100 {
+		c.InterestRate = 100
+	}
+	if c.InterestCap < 0 {
+		c.InterestCap = 0
+	}
+
 	// Clamp visual values
 	if c.GridSize < 20 {
 		c.GridSize = 20
@@ -303,6 +636,33 @@ func (c *GameConfig) ValidateConfig() {
 	if c.ParticleDensity > 2 {
 		c.ParticleDensity = 2
 	}
+	if c.MaxParticles < 0 {
+		c.MaxParticles = 0
+	}
+	if c.MaxParticles > 50000 {
+		c.MaxParticles = 50000
+	}
+	if c.UIScale < 0.5 {
+		c.UIScale = 0.5
+	}
+	if c.UIScale > 3 {
+		c.UIScale = 3
+	}
+	if c.GamepadDeadzone < 0.05 {
+		c.GamepadDeadzone = 0.05
+	}
+	if c.GamepadDeadzone > 0.95 {
+		c.GamepadDeadzone = 0.95
+	}
+	if c.GamepadCursorRepeat < 0.02 {
+		c.GamepadCursorRepeat = 0.02
+	}
+	if c.Language == "" {
+		c.Language = "en"
+	}
+	if c.Theme == "" {
+		c.Theme = defaultTheme
+	}
 
 	// Clamp audio values
 	if c.MasterVolume < 0 {
@@ -325,52 +685,119 @@ func (c *GameConfig) ValidateConfig() {
 	}
 }
 
+// Tower types, numbered as on the tower bar and by the tower select keys
+const (
+	TowerBasic = iota + 1
+	TowerHeavy
+	TowerSniper
+	TowerLaser
+	TowerSplash
+	TowerSlow
+	TowerBank // Support structures from here on
+	TowerRadar
+	TowerArmory
+)
+
 // GetTowerStats returns tower statistics based on tower type
 func (c *GameConfig) GetTowerStats(towerType int) (cost int, damage int, rangeVal float64, fireRate float64) {
 	switch towerType {
-	case 1: // Basic Tower
+	case TowerBasic:
 		return c.BasicTowerCost, c.BasicTowerDamage, c.BasicTowerRange, c.BasicTowerRate
-	case 2: // Heavy Tower
+	case TowerHeavy:
 		return c.HeavyTowerCost, c.HeavyTowerDamage, c.HeavyTowerRange, c.HeavyTowerRate
-	case 3: // Sniper Tower
+	case TowerSniper:
 		return c.SniperTowerCost, c.SniperTowerDamage, c.SniperTowerRange, c.SniperTowerRate
-	case 4: // Laser Tower
+	case TowerLaser:
 		return c.LaserTowerCost, c.LaserTowerDamage, c.LaserTowerRange, c.LaserTowerRate
-	case 5: // Splash Tower
+	case TowerSplash:
 		return c.SplashTowerCost, c.SplashTowerDamage, c.SplashTowerRange, c.SplashTowerRate
-	case 6: // Slow Tower
+	case TowerSlow:
 		return c.SlowTowerCost, c.SlowTowerDamage, c.SlowTowerRange, c.SlowTowerRate
+	case TowerBank: // No combat stats
+		return c.BankCost, 0, 0, 0
+	case TowerRadar: // Range is its effect radius
+		return c.RadarCost, 0, c.RadarRadius, 0
+	case TowerArmory: // Range covers the adjacent grid cells
+		return c.ArmoryCost, 0, float64(c.GridSize) * 1.5, 0
 	default:
 		return c.BasicTowerCost, c.BasicTowerDamage, c.BasicTowerRange, c.BasicTowerRate
 	}
 }
 
+// GetTowerTurnRate returns how fast a tower type's turret turns in radians per second, or 0
+// for towers without a turret, which fire in any direction
+func (c *GameConfig) GetTowerTurnRate(towerType int) float64 {
+	switch towerType {
+	case TowerBasic:
+		return c.BasicTowerTurnRate * math.Pi / 180
+	case TowerHeavy:
+		return c.HeavyTowerTurnRate * math.Pi / 180
+	case TowerSniper:
+		return c.SniperTowerTurnRate * math.Pi / 180
+	default:
+		return 0
+	}
+}
+
 // GetTowerName returns the name of a tower type
 func (c *GameConfig) GetTowerName(towerType int) string {
 	switch towerType {
-	case 1:
+	case TowerBasic:
 		return "Basic"
-	case 2:
+	case TowerHeavy:
 		return "Heavy"
-	case 3:
+	case TowerSniper:
 		return "Sniper"
-	case 4:
+	case TowerLaser:
 		return "Laser"
-	case 5:
+	case TowerSplash:
 		return "Splash"
-	case 6:
+	case TowerSlow:
 		return "Slow"
+	case TowerBank:
+		return "Bank"
+	case TowerRadar:
+		return "Radar"
+	case TowerArmory:
+		return "Armory"
 	default:
 		return "Unknown"
 	}
 }
 
+// IsSupportStructure reports whether a tower type is a non-combat support structure
+func (c *GameConfig) IsSupportStructure(towerType int) bool {
+	return towerType >= TowerBank && towerType <= TowerArmory
+}
+
 // GetEnemyHealth returns the health for an enemy in the given wave
 func (c *GameConfig) GetEnemyHealth(wave int) int {
 	return c.BaseEnemyHealth + (wave-1)*c.HealthPerWave
 }
 
+// CalculateInterest returns the interest paid on unspent money at a wave transition
+func (c *GameConfig) CalculateInterest(money int) int {
+	if !c.InterestEnabled || money <= 0 {
+		return 0
+	}
+
+	interest := int(float64(money) * c.InterestRate / 100)
+	return min(interest, c.InterestCap)
+}
+
 // GetEnemiesInWave returns the number of enemies that should spawn in the given wave
 func (c *GameConfig) GetEnemiesInWave(wave int) int {
 	return c.EnemiesPerWave * wave
 }
+
+// MapSize returns the map size in grid cells; a zero dimension fits the window
+func (c *GameConfig) MapSize() (int, int) {
+	width, height := c.MapWidth, c.MapHeight
+	if width == 0 {
+		width = c.WindowWidth / c.GridSize
+	}
+	if height == 0 {
+		height = c.WindowHeight / c.GridSize
+	}
+	return width, height
+}
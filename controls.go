@@ -0,0 +1,289 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Controls screen layout
+const (
+	controlsNameWidth  = 150
+	controlsKeyWidth   = 170 // Room for controlsMaxKeys key buttons
+	controlsSlotWidth  = 54
+	controlsKeyHeight  = 20
+	controlsMaxKeys    = 3
+	controlsColumnRows = 18
+)
+
+// openControls shows the controls screen from the main menu
+func (gmm *GameModeManager) openControls(game *Game) {
+	gmm.CurrentState = StateControls
+	gmm.Rebinding = false
+	gmm.EscapePending = false
+}
+
+// closeControls returns to the main menu; unsaved bindings stay active for this session
+func (gmm *GameModeManager) closeControls(game *Game) {
+	gmm.CurrentState = StateMenu
+	gmm.Rebinding = false
+	gmm.EscapePending = false
+}
+
+// updateControls binds the next key pressed while a binding is being changed, or cancels on
+// Escape; pressing Escape again right after binds Escape itself. Otherwise the pause key leaves
+// the screen.
+func (gmm *GameModeManager) updateControls(game *Game) error {
+	keys := game.input.JustPressedKeys()
+	if gmm.Rebinding {
+		if len(keys) == 0 {
+			return nil
+		}
+		if keys[0] == ebiten.KeyEscape {
+			gmm.Rebinding = false
+			gmm.EscapePending = true
+			gmm.ControlsStatus = game.loc.T("controls.cancelled")
+			return nil
+		}
+		// The key is taken as it is; it was pressed before being bound, so it won't act on release.
+		// A key already in use leaves the screen listening for another one.
+		if game.rebindAction(gmm.RebindAction, gmm.RebindSlot, keys[0]) {
+			gmm.Rebinding = false
+		}
+		return nil
+	}
+
+	if gmm.EscapePending && len(keys) > 0 {
+		gmm.EscapePending = false
+		if keys[0] == ebiten.KeyEscape {
+			game.input.Consume(ActionPause)
+			game.rebindAction(gmm.RebindAction, gmm.RebindSlot, ebiten.KeyEscape)
+			return nil
+		}
+	}
+
+	if game.input.JustPressed(ActionPause) {
+		game.input.Consume(ActionPause)
+		gmm.closeControls(game)
+	}
+	return nil
+}
+
+// toggleRebind starts listening for a new key for one key slot of an action, or stops if it
+// was already listening there
+func (gmm *GameModeManager) toggleRebind(action Action, slot int) {
+	gmm.EscapePending = false
+	if gmm.Rebinding && gmm.RebindAction == action && gmm.RebindSlot == slot {
+		gmm.Rebinding = false
+		return
+	}
+	gmm.Rebinding = true
+	gmm.RebindAction = action
+	gmm.RebindSlot = slot
+}
+
+// rebindAction replaces the key at slot of an action's keys, or adds the key when slot is past
+// the last one, and rebuilds the input map; the action's other keys are kept. A key the action
+// already has, or that another action of the same context uses, is refused; it reports whether
+// the key was bound.
+func (g *Game) rebindAction(action Action, slot int, key ebiten.Key) bool {
+	for _, binding := range actionBindings {
+		if binding.Action != action || binding.Field == nil {
+			continue
+		}
+
+		keys := slices.Clone(g.input.Keys(action))
+		if index := slices.Index(keys, key); index >= 0 && index != slot {
+			g.modeManager.ControlsStatus = g.loc.T("controls.in_use", keyName(key), g.actionName(binding))
+			return false
+		}
+		if other, ok := g.actionUsingKey(action, key); ok {
+			g.modeManager.ControlsStatus = g.loc.T("controls.in_use", keyName(key), g.actionName(other))
+			return false
+		}
+
+		if slot < len(keys) {
+			keys[slot] = key
+		} else {
+			keys = append(keys, key)
+		}
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = keyName(k)
+		}
+		*binding.Field(g.config) = strings.Join(names, ",")
+		g.reloadInputMap()
+		g.modeManager.ControlsStatus = g.loc.T("controls.bound", g.actionName(binding), keyName(key))
+		return true
+	}
+	return false
+}
+
+// actionUsingKey finds an action other than action that key is bound to in the same context
+func (g *Game) actionUsingKey(action Action, key ebiten.Key) (actionBinding, bool) {
+	for _, binding := range actionBindings {
+		if binding.Field == nil || binding.Action == action || !action.conflictsWith(binding.Action) {
+			continue
+		}
+		if keys, err := parseKeyList(*binding.Field(g.config)); err == nil && slices.Contains(keys, key) {
+			return binding, true
+		}
+	}
+	return actionBinding{}, false
+}
+
+// resetControls restores the default key of every action
+func (g *Game) resetControls() {
+	defaults := DefaultConfig()
+	for _, binding := range actionBindings {
+		if binding.Field != nil {
+			*binding.Field(g.config) = *binding.Field(defaults)
+		}
+	}
+	g.reloadInputMap()
+	g.modeManager.Rebinding = false
+	g.modeManager.EscapePending = false
+	g.modeManager.ControlsStatus = g.loc.T("controls.reset")
+}
+
+// saveControls writes the current key bindings to the config file. The file is read again and
+// only its bindings are replaced: the live config also holds values the game changes while
+// playing, such as the current level's enemy stats, which must not become the saved defaults.
+func (g *Game) saveControls() {
+	g.modeManager.Rebinding = false
+	g.modeManager.EscapePending = false
+	saved, err := LoadConfig(g.configFile)
+	if err == nil {
+		for _, binding := range actionBindings {
+			if binding.Field != nil {
+				*binding.Field(saved) = *binding.Field(g.config)
+			}
+		}
+		err = saved.SaveConfig(g.configFile)
+	}
+	if err != nil {
+		g.modeManager.ControlsStatus = g.loc.T("controls.save_failed", err)
+		return
+	}
+	g.modeManager.ControlsStatus = g.loc.T("controls.saved", g.configFile)
+}
+
+// reloadInputMap rebuilds the input map after the bindings in the config changed
+func (g *Game) reloadInputMap() {
+	bindings, err := NewInputMap(g.config)
+	if err != nil {
+		g.modeManager.ControlsStatus = err.Error()
+	}
+	g.input.SetBindings(bindings)
+}
+
+// actionName returns the localized name of an action
+func (g *Game) actionName(binding actionBinding) string {
+	return g.loc.T("action." + binding.Name)
+}
+
+// newControlsPanel builds the controls screen: one row per action with a button to rebind it
+func newControlsPanel(g *Game) *Panel {
+	gmm := g.modeManager
+
+	// Gamepad-only actions are mapped in the config file and have no row
+	columns := &Panel{Direction: LayoutHorizontal, Align: AlignCenter, Spacing: 16}
+	var column *Panel
+	rows := 0
+	for _, binding := range actionBindings {
+		if binding.Field == nil {
+			continue
+		}
+		if rows%controlsColumnRows == 0 {
+			column = &Panel{Spacing: 4}
+			columns.Children = append(columns.Children, column)
+		}
+		column.Children = append(column.Children, newControlsRow(g, binding))
+		rows++
+	}
+
+	return &Panel{
+		PositionFunc: centerOnScreen(g),
+		Padding:      16,
+		Spacing:      8,
+		Background:   g.theme.Panel,
+		Border:       g.theme.PanelBorder,
+		Children: []Widget{
+			&Label{Text: g.loc.T("controls.title"), Style: TextHeading, Align: AlignCenter, Color: g.theme.Title},
+			&Label{TextFunc: func() string {
+				if gmm.Rebinding {
+					for _, binding := range actionBindings {
+						if binding.Action == gmm.RebindAction {
+							return g.loc.T("controls.listening", g.actionName(binding))
+						}
+					}
+				}
+				return g.loc.T("controls.hint")
+			}, Style: TextHUD, Align: AlignCenter},
+			columns,
+			&Label{TextFunc: func() string { return gmm.ControlsStatus }, Style: TextHUD, Align: AlignCenter},
+			&Panel{
+				Direction: LayoutHorizontal,
+				Align:     AlignCenter,
+				Spacing:   12,
+				Children: []Widget{
+					&Button{Label: g.loc.T("controls.button.reset"), W: 140, OnClick: g.resetControls},
+					&Button{Label: g.loc.T("controls.button.save"), W: 140, OnClick: g.saveControls},
+					&Button{
+						LabelFunc: func() string { return g.loc.T("controls.button.back", g.input.Label(ActionPause)) },
+						W:         140,
+						OnClick:   func() { gmm.closeControls(g) },
+					},
+				},
+			},
+		},
+	}
+}
+
+// newControlsRow shows an action's name and a button for each of its keys, followed by one
+// that adds a key
+func newControlsRow(g *Game, binding actionBinding) *Panel {
+	slots := &Panel{Direction: LayoutHorizontal, Spacing: 4, MinWidth: controlsKeyWidth}
+	for slot := 0; slot < controlsMaxKeys; slot++ {
+		slots.Children = append(slots.Children, newControlsSlot(g, binding.Action, slot))
+	}
+	return &Panel{
+		Direction: LayoutHorizontal,
+		Spacing:   8,
+		Children: []Widget{
+			&Panel{
+				MinWidth: controlsNameWidth,
+				Children: []Widget{&Label{Text: g.actionName(binding), Style: TextHUD}},
+			},
+			slots,
+		},
+	}
+}
+
+// newControlsSlot shows the key at slot of an action's keys, clicked to replace it, or "+" to
+// add a key when slot is just past the last one
+func newControlsSlot(g *Game, action Action, slot int) *Panel {
+	gmm := g.modeManager
+	listening := func() bool { return gmm.Rebinding && gmm.RebindAction == action && gmm.RebindSlot == slot }
+	return &Panel{
+		VisibleFunc: func() bool { return slot <= len(g.input.Keys(action)) },
+		Children: []Widget{&Button{
+			LabelFunc: func() string {
+				keys := g.input.Keys(action)
+				switch {
+				case listening():
+					return "..."
+				case slot < len(keys):
+					return keyName(keys[slot])
+				default:
+					return "+"
+				}
+			},
+			W:            controlsSlotWidth,
+			H:            controlsKeyHeight,
+			SelectedFunc: listening,
+			OnClick:      func() { gmm.toggleRebind(action, slot) },
+		}},
+	}
+}
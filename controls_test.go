@@ -0,0 +1,66 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestDefaultKeysDoNotConflict(t *testing.T) {
+	defaults := DefaultConfig()
+	for i, a := range actionBindings {
+		if a.Field == nil {
+			continue
+		}
+		keysA, err := parseKeyList(*a.Field(defaults))
+		if err != nil {
+			t.Fatalf("%s: %v", a.Name, err)
+		}
+		for _, b := range actionBindings[i+1:] {
+			if b.Field == nil || !a.Action.conflictsWith(b.Action) {
+				continue
+			}
+			keysB, _ := parseKeyList(*b.Field(defaults))
+			for _, key := range keysA {
+				if slices.Contains(keysB, key) {
+					t.Errorf("%s and %s are both bound to %s", a.Name, b.Name, keyName(key))
+				}
+			}
+		}
+	}
+}
+
+func TestRebindAction(t *testing.T) {
+	game := NewGame(DefaultConfig())
+	config := game.config
+
+	steps := []struct {
+		name   string
+		action Action
+		slot   int
+		key    ebiten.Key
+		bound  bool
+		field  *string
+		want   string
+	}{
+		{"replace a key shared with a menu action", ActionNextWave, 0, ebiten.KeyN, true, &config.NextWaveKey, "N"},
+		{"back to the default", ActionNextWave, 0, ebiten.KeySpace, true, &config.NextWaveKey, "Space"},
+		{"replace one of two keys", ActionMenuUp, 1, ebiten.KeyI, true, &config.MenuUpKey, "ArrowUp,I"},
+		{"back to a key the camera uses", ActionMenuUp, 1, ebiten.KeyW, true, &config.MenuUpKey, "ArrowUp,W"},
+		{"add a key", ActionConfirm, 2, ebiten.KeyK, true, &config.ConfirmKey, "Enter,Space,K"},
+		{"key of the same context", ActionConfirm, 1, ebiten.KeyArrowDown, false, &config.ConfirmKey, "Enter,Space,K"},
+		{"key the action already has", ActionConfirm, 0, ebiten.KeySpace, false, &config.ConfirmKey, "Enter,Space,K"},
+		{"pause key", ActionCameraReset, 0, ebiten.KeyP, false, &config.CameraResetKey, "Home"},
+		{"move pause off Escape", ActionPause, 0, ebiten.KeyF1, true, &config.PauseKey, "F1,P"},
+		{"bind Escape again", ActionPause, 0, ebiten.KeyEscape, true, &config.PauseKey, "Escape,P"},
+	}
+	for _, step := range steps {
+		if bound := game.rebindAction(step.action, step.slot, step.key); bound != step.bound {
+			t.Errorf("%s: bound %v, want %v (%s)", step.name, bound, step.bound, game.modeManager.ControlsStatus)
+		}
+		if *step.field != step.want {
+			t.Errorf("%s: keys are %q, want %q", step.name, *step.field, step.want)
+		}
+	}
+}
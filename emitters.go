@@ -0,0 +1,364 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+)
+
+// emitterFile holds the particle emitters of an assets directory and the effects using them
+const emitterFile = "emitters.json"
+
+// Emitter modes: a burst spawns all its particles at once, a continuous emitter spawns them at
+// a steady rate for as long as its owner keeps emitting
+const (
+	emitterBurst      = "burst"
+	emitterContinuous = "continuous"
+)
+
+// Blend modes: additive particles brighten what is behind them, like sparks and energy
+const (
+	blendAlpha    = "alpha"
+	blendAdditive = "additive"
+)
+
+// Effect events, the moments an entity emits particles
+const (
+	effectTrail          = "trail"           // Every tick while moving
+	effectDeath          = "death"           // Enemy killed
+	effectFire           = "fire"            // Tower fired, at the barrel tip
+	effectImpact         = "impact"          // Projectile hit its target
+	effectCriticalImpact = "critical_impact" // Projectile hit with a critical
+	effectSplash         = "splash"          // Each enemy caught in a splash
+	effectBonus          = "bonus"           // Early wave completion bonus
+)
+
+// EmitterDef is an emitter as written in emitters.json. Speeds are in pixels per second,
+// angles in degrees with 0 pointing right and 90 down.
+type EmitterDef struct {
+	Mode      string     `json:"mode"`      // "burst" or "continuous"
+	Count     int        `json:"count"`     // Particles per burst
+	Rate      float64    `json:"rate"`      // Particles per second of a continuous emitter
+	Direction float64    `json:"direction"` // Center of the spread
+	Spread    float64    `json:"spread"`    // Width of the cone particles leave in; 360 for all around
+	Speed     [2]float64 `json:"speed"`     // Min and max launch speed
+	Inherit   float64    `json:"inherit"`   // Share of the owner's velocity added; negative trails behind
+	Life      [2]float64 `json:"life"`      // Min and max lifetime in seconds
+	Colors    []string   `json:"colors"`    // Gradient over the lifetime: theme roles or "#rrggbb[aa]"
+	Size      [2]float64 `json:"size"`      // Radius at birth and at death
+	Gravity   float64    `json:"gravity"`   // Downward acceleration in pixels per second squared
+	Fade      bool       `json:"fade"`      // Alpha falls with the remaining lifetime
+	Blend     string     `json:"blend"`     // "alpha" (default) or "additive"
+	Offset    [2]float64 `json:"offset"`    // Spawn point relative to the owner
+}
+
+// emitterManifest is the content of emitters.json. Effects map an entity ("enemy", "tower_3",
+// "projectile_5", ...) to the emitter of each event; "tower_N" and "projectile_N" fall back
+// to the "tower" and "projectile" entries.
+type emitterManifest struct {
+	Emitters map[string]EmitterDef        `json:"emitters"`
+	Effects  map[string]map[string]string `json:"effects"`
+}
+
+// Emitter is a loaded emitter with its values converted to simulation ticks
+type Emitter struct {
+	Name      string
+	burst     bool
+	count     int
+	rate      float64 // Particles per tick
+	direction float64 // Radians
+	spread    float64 // Radians
+	minSpeed  float64 // Pixels per tick
+	maxSpeed  float64
+	inherit   float64
+	minLife   float64 // Seconds
+	maxLife   float64
+	colors    []color.RGBA
+	startSize float32
+	endSize   float32
+	gravity   float64 // Pixels per tick squared
+	fade      bool
+	additive  bool
+	offsetX   float64
+	offsetY   float64
+}
+
+// projectileEffectKey names the effect entry of projectiles fired by a tower type
+func projectileEffectKey(towerType int) string {
+	return fmt.Sprintf("projectile_%d", towerType)
+}
+
+// LoadEmitters loads the embedded emitters and effects, then those in dir, which replace
+// entries of the same name. Colors naming theme roles take the graphics theme's colors.
+// Invalid emitters are skipped and effects using them show nothing; the returned error
+// lists them.
+func (gm *GraphicsManager) LoadEmitters(dir string) error {
+	var problems []string
+
+	sources := []fs.FS{}
+	if sub, err := fs.Sub(embeddedAssets, "assets"); err == nil {
+		sources = append(sources, sub)
+	}
+	if dir != "" {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			sources = append(sources, os.DirFS(dir))
+		}
+	}
+
+	for _, source := range sources {
+		manifest, err := readEmitterManifest(source)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		names := make([]string, 0, len(manifest.Emitters))
+		for name := range manifest.Emitters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			emitter, err := newEmitter(name, manifest.Emitters[name], gm.Theme)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("emitter %s: %v", name, err))
+				continue
+			}
+			gm.Emitters[name] = emitter
+		}
+
+		for key, events := range manifest.Effects {
+			if gm.Effects[key] == nil {
+				gm.Effects[key] = make(map[string]string)
+			}
+			for event, name := range events {
+				gm.Effects[key][event] = name
+			}
+		}
+	}
+
+	// Report effects naming emitters that don't exist
+	keys := make([]string, 0, len(gm.Effects))
+	for key := range gm.Effects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for event, name := range gm.Effects[key] {
+			if name != "" && gm.Emitters[name] == nil {
+				problems = append(problems, fmt.Sprintf("effect %s.%s: unknown emitter %q", key, event, name))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid particle emitters: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// readEmitterManifest parses the emitters of an assets directory; a missing file is empty
+func readEmitterManifest(source fs.FS) (emitterManifest, error) {
+	var manifest emitterManifest
+	data, err := fs.ReadFile(source, emitterFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("parsing %s: %v", emitterFile, err)
+	}
+	return manifest, nil
+}
+
+// newEmitter checks an emitter definition and converts it to ticks and theme colors
+func newEmitter(name string, def EmitterDef, theme *Theme) (*Emitter, error) {
+	e := &Emitter{
+		Name:      name,
+		count:     def.Count,
+		rate:      def.Rate / 60.0,
+		direction: def.Direction * math.Pi / 180,
+		spread:    def.Spread * math.Pi / 180,
+		minSpeed:  def.Speed[0] / 60.0,
+		maxSpeed:  def.Speed[1] / 60.0,
+		inherit:   def.Inherit,
+		minLife:   def.Life[0],
+		maxLife:   def.Life[1],
+		startSize: float32(def.Size[0]),
+		endSize:   float32(def.Size[1]),
+		gravity:   def.Gravity / 3600.0,
+		fade:      def.Fade,
+		offsetX:   def.Offset[0],
+		offsetY:   def.Offset[1],
+	}
+
+	switch def.Mode {
+	case emitterBurst:
+		e.burst = true
+		if def.Count <= 0 {
+			return nil, fmt.Errorf("a burst needs a positive count")
+		}
+	case emitterContinuous:
+		if def.Rate <= 0 {
+			return nil, fmt.Errorf("a continuous emitter needs a positive rate")
+		}
+	default:
+		return nil, fmt.Errorf("mode must be %q or %q", emitterBurst, emitterContinuous)
+	}
+
+	switch def.Blend {
+	case "", blendAlpha:
+	case blendAdditive:
+		e.additive = true
+	default:
+		return nil, fmt.Errorf("blend must be %q or %q", blendAlpha, blendAdditive)
+	}
+
+	if def.Life[0] <= 0 || def.Life[1] < def.Life[0] {
+		return nil, fmt.Errorf("life must be a positive [min, max] range")
+	}
+	if def.Speed[1] < def.Speed[0] {
+		return nil, fmt.Errorf("speed must be a [min, max] range")
+	}
+	if def.Size[0] < 0 || def.Size[1] < 0 {
+		return nil, fmt.Errorf("size must not be negative")
+	}
+
+	if len(def.Colors) == 0 {
+		return nil, fmt.Errorf("needs at least one color")
+	}
+	roles := theme.roles()
+	for _, value := range def.Colors {
+		if strings.HasPrefix(value, "#") {
+			clr, err := parseHexColor(value)
+			if err != nil {
+				return nil, err
+			}
+			e.colors = append(e.colors, clr)
+		} else if role, ok := roles[value]; ok {
+			e.colors = append(e.colors, *role)
+		} else {
+			return nil, fmt.Errorf("unknown color role %q", value)
+		}
+	}
+	return e, nil
+}
+
+// colorAt returns the gradient color at t, the share of the lifetime used up (0-1)
+func (e *Emitter) colorAt(t float64) color.RGBA {
+	if len(e.colors) == 1 {
+		return e.colors[0]
+	}
+	pos := math.Max(0, math.Min(t, 1)) * float64(len(e.colors)-1)
+	i := int(pos)
+	if i >= len(e.colors)-1 {
+		return e.colors[len(e.colors)-1]
+	}
+	return lerpColor(e.colors[i], e.colors[i+1], pos-float64(i))
+}
+
+// sizeAt returns the particle radius at t, the share of the lifetime used up (0-1)
+func (e *Emitter) sizeAt(t float64) float32 {
+	return e.startSize + (e.endSize-e.startSize)*float32(t)
+}
+
+// emitterFor returns the emitter an entity uses for an event, or nil when it has none
+func (gm *GraphicsManager) emitterFor(key, event string) *Emitter {
+	if name, ok := gm.Effects[key][event]; ok {
+		return gm.Emitters[name]
+	}
+	if base, _, found := strings.Cut(key, "_"); found {
+		return gm.Emitters[gm.Effects[base][event]]
+	}
+	return nil
+}
+
+// EmitEffect spawns the particles of an entity's effect at a position. velocity is the
+// owner's own movement in pixels per second, which continuous emitters can inherit; they
+// spawn one tick's worth of particles per call.
+func (gm *GraphicsManager) EmitEffect(key, event string, position, velocity Point, config *GameConfig) {
+	emitter := gm.emitterFor(key, event)
+	if emitter == nil || config.ParticleDensity <= 0 {
+		return
+	}
+
+	count := 0
+	if emitter.burst {
+		count = int(math.Round(float64(emitter.count) * config.ParticleDensity))
+	} else {
+		// Whole particles every tick, plus a chance of one more for the fraction
+		expected := emitter.rate * config.ParticleDensity
+		count = int(expected)
+		if rand.Float64() < expected-float64(count) {
+			count++
+		}
+	}
+
+	for i := 0; i < count; i++ {
+		var angle float64
+		if emitter.burst {
+			// Bursts spread their particles evenly over the cone
+			angle = emitter.direction + emitter.spread*((float64(i)+0.5)/float64(count)-0.5)
+		} else {
+			angle = emitter.direction + emitter.spread*(rand.Float64()-0.5)
+		}
+		speed := emitter.minSpeed + rand.Float64()*(emitter.maxSpeed-emitter.minSpeed)
+		life := emitter.minLife + rand.Float64()*(emitter.maxLife-emitter.minLife)
+
+		gm.ParticleSystem.Spawn(Particle{
+			Position: Point{position.X + emitter.offsetX, position.Y + emitter.offsetY},
+			Velocity: Point{
+				math.Cos(angle)*speed + velocity.X/60.0*emitter.inherit,
+				math.Sin(angle)*speed + velocity.Y/60.0*emitter.inherit,
+			},
+			Life:    life,
+			MaxLife: life,
+			Gravity: emitter.gravity,
+			Emitter: emitter,
+		})
+	}
+}
+
+// EmitTrails spawns this tick's trail particles behind moving enemies and projectiles
+func (gm *GraphicsManager) EmitTrails(enemies []*Enemy, projectiles []*Projectile, config *GameConfig) {
+	if config.ParticleDensity <= 0 {
+		return
+	}
+	for _, enemy := range enemies {
+		if enemy.Alive && enemy.Speed > 0 {
+			gm.EmitEffect("enemy", effectTrail, enemy.Position, headingVelocity(enemy.Position, enemy.Target, enemy.Speed), config)
+		}
+	}
+	for _, proj := range projectiles {
+		if proj.Active && proj.Target != nil {
+			gm.EmitEffect(proj.effectKey(), effectTrail, proj.Position, headingVelocity(proj.Position, proj.Target.Position, proj.Speed), config)
+		}
+	}
+}
+
+// headingVelocity returns the velocity in pixels per second of something moving from one
+// point toward another at speed pixels per tick
+func headingVelocity(from, to Point, speed float64) Point {
+	dx, dy := to.X-from.X, to.Y-from.Y
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance == 0 {
+		return Point{}
+	}
+	return Point{dx / distance * speed * 60, dy / distance * speed * 60}
+}
+
+// effectKey names the effect entry of a projectile, after the tower that fired it
+func (p *Projectile) effectKey() string {
+	if p.Source == nil {
+		return "projectile"
+	}
+	return projectileEffectKey(p.Source.Type)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// TextStyle selects the font size and weight used for a piece of UI text
+type TextStyle int
+
+const (
+	TextBody TextStyle = iota
+	TextHUD
+	TextTooltip
+	TextHeading
+	TextTitle
+)
+
+// textStyleSpec describes the font of a text style at a UI scale of 1
+type textStyleSpec struct {
+	Size float64
+	Bold bool
+}
+
+var textStyleSpecs = map[TextStyle]textStyleSpec{
+	TextBody:    {Size: 14},
+	TextHUD:     {Size: 13},
+	TextTooltip: {Size: 12},
+	TextHeading: {Size: 20, Bold: true},
+	TextTitle:   {Size: 34, Bold: true},
+}
+
+// FontManager holds the embedded TrueType fonts and a face per text style at the current scale
+type FontManager struct {
+	Scale       float64     // UI scale times the screen's pixel scale
+	Shadow      color.Color // Drop shadow behind all text
+	LogMissing  bool        // Log each character the fonts have no glyph for, once
+	regular     *opentype.Font
+	bold        *opentype.Font
+	faces       map[TextStyle]font.Face
+	missingSeen map[rune]bool
+}
+
+// NewFontManager parses the embedded Go fonts and builds faces for every text style
+func NewFontManager(scale float64) *FontManager {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		log.Fatalf("Error parsing regular font: %v", err)
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		log.Fatalf("Error parsing bold font: %v", err)
+	}
+
+	fm := &FontManager{Shadow: color.RGBA{0, 0, 0, 200}, regular: regular, bold: bold}
+	fm.SetScale(scale)
+	return fm
+}
+
+// SetScale rebuilds the faces for a new scale, e.g. after the window moved to a HiDPI monitor
+func (fm *FontManager) SetScale(scale float64) {
+	if scale == fm.Scale && fm.faces != nil {
+		return
+	}
+	fm.Scale = scale
+	fm.faces = make(map[TextStyle]font.Face)
+	for style, spec := range textStyleSpecs {
+		source := fm.regular
+		if spec.Bold {
+			source = fm.bold
+		}
+		face, err := opentype.NewFace(source, &opentype.FaceOptions{
+			Size:    spec.Size * scale,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			log.Fatalf("Error creating font face: %v", err)
+		}
+		fm.faces[style] = face
+	}
+}
+
+// Face returns the font face of a text style
+func (fm *FontManager) Face(style TextStyle) font.Face {
+	return fm.faces[style]
+}
+
+// Px scales a layout size in pixels by the UI scale
+func (fm *FontManager) Px(size int) int {
+	return int(math.Round(float64(size) * fm.Scale))
+}
+
+// LineHeight returns the distance between lines of a text style
+func (fm *FontManager) LineHeight(style TextStyle) int {
+	return fm.Face(style).Metrics().Height.Ceil()
+}
+
+// renderable drops characters the font has no glyph for so they don't show up as boxes. With
+// LogMissing set, each dropped character is logged the first time, so text that loses a
+// symbol is noticed.
+func (fm *FontManager) renderable(style TextStyle, s string) string {
+	face := fm.Face(style)
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == ' ' {
+			return r
+		}
+		if _, ok := face.GlyphAdvance(r); !ok {
+			if fm.LogMissing && !fm.missingSeen[r] {
+				if fm.missingSeen == nil {
+					fm.missingSeen = make(map[rune]bool)
+				}
+				fm.missingSeen[r] = true
+				log.Printf("Font has no glyph for %q (U+%04X) in %q; it is left out", r, r, s)
+			}
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Measure returns the pixel size of a (possibly multi-line) string
+func (fm *FontManager) Measure(style TextStyle, s string) (int, int) {
+	if s == "" {
+		return 0, 0
+	}
+	face := fm.Face(style)
+	lines := strings.Split(fm.renderable(style, s), "\n")
+	width := 0
+	for _, line := range lines {
+		width = maxInt(width, font.MeasureString(face, line).Ceil())
+	}
+	return width, len(lines) * fm.LineHeight(style)
+}
+
+// Draw renders text with its top-left corner at x, y and a drop shadow for readability
+func (fm *FontManager) Draw(screen *ebiten.Image, style TextStyle, s string, x, y int, clr color.Color) {
+	face := fm.Face(style)
+	ascent := face.Metrics().Ascent.Ceil()
+	lineHeight := fm.LineHeight(style)
+	shadow := fm.Px(1)
+
+	for i, line := range strings.Split(fm.renderable(style, s), "\n") {
+		baseline := y + ascent + i*lineHeight
+		text.Draw(screen, line, face, x+shadow, baseline+shadow, fm.Shadow)
+		text.Draw(screen, line, face, x, baseline, clr)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"sort"
+	"testing"
+)
+
+// TestLocaleStringsRender checks that the fonts of every text style have a glyph for every
+// character of every string table, since renderable drops the ones they lack
+func TestLocaleStringsRender(t *testing.T) {
+	fm := NewFontManager(1)
+	for _, language := range AvailableLanguages() {
+		table, err := loadStringTable(language)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys := make([]string, 0, len(table))
+		for key := range table {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			entry := table[key]
+			texts := []string{entry.Text}
+			for _, form := range entry.Forms {
+				texts = append(texts, form)
+			}
+			for _, text := range texts {
+				for style := range textStyleSpecs {
+					if drawn := fm.renderable(style, text); drawn != text {
+						t.Errorf("%s: %s: style %d draws %q as %q", language, key, style, text, drawn)
+					}
+				}
+			}
+		}
+	}
+}
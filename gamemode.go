@@ -2,11 +2,10 @@ package main
 
 import (
 	"fmt"
-	"image/color"
 	"math"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
@@ -17,6 +16,7 @@ const (
 	GameModeMenu GameMode = iota
 	GameModeNormal
 	GameModeEndless
+	GameModeSandbox
 )
 
 // GameState represents the current state of the game
@@ -28,19 +28,29 @@ const (
 	StateGameOver
 	StateVictory
 	StatePaused
+	StateControls
 )
 
 // LevelData contains information about a specific level
 type LevelData struct {
-	LevelNumber   int
-	EnemyCount    int
-	EnemyHealth   int
-	EnemySpeed    float64
-	SpawnDelay    float64
-	StartingMoney int
-	WaveBonus     int
-	Description   string
-	RequiredKills int
+	LevelNumber    int
+	EnemyCount     int
+	EnemyHealth    int
+	EnemySpeed     float64
+	SpawnDelay     float64
+	StartingMoney  int
+	WaveBonus      int
+	DescriptionKey string // Localization key of the level's flavor text
+	RequiredKills  int
+}
+
+// WaveSummary records the money paid out when the previous wave ended
+type WaveSummary struct {
+	Wave       int
+	WaveBonus  int
+	EarlyBonus int
+	Interest   int
+	BankIncome int
 }
 
 // GameModeManager handles game mode logic and level progression
@@ -57,10 +67,19 @@ type GameModeManager struct {
 	TransitionTimer   float64
 	ShowLevelInfo     bool
 	LevelInfoTimer    float64
-	KeyUpPressed      bool
-	KeyDownPressed    bool
-	KeyEnterPressed   bool
-	KeySpacePressed   bool
+	MenuClicked       bool // A menu option was clicked this frame
+	LastSummary       WaveSummary
+
+	// Sandbox spawning controls
+	SandboxHealth int
+	SandboxSpeed  float64
+
+	// Controls screen
+	Rebinding      bool // Waiting for a key to bind to RebindAction
+	RebindAction   Action
+	RebindSlot     int    // Index of the key to replace; one past the last key adds a key
+	EscapePending  bool   // Escape cancelled the last rebind; pressing it again binds Escape
+	ControlsStatus string // Result of the last rebind, reset or save
 }
 
 // NewGameModeManager creates a new game mode manager
@@ -71,7 +90,7 @@ func NewGameModeManager() *GameModeManager {
 		CurrentLevel:  1,
 		MaxLevel:      10,
 		MenuSelection: 0,
-		MenuOptions:   []string{"Normal Mode", "Endless Mode", "Exit Game"},
+		MenuOptions:   buildMenuOptions(nil),
 		LevelData:     generateLevelData(nil),
 	}
 	return gmm
@@ -84,7 +103,7 @@ func NewGameModeManagerWithDebug(debugMode bool, config *GameConfig) *GameModeMa
 		CurrentLevel:  1,
 		MaxLevel:      10,
 		MenuSelection: 0,
-		MenuOptions:   []string{"Normal Mode", "Endless Mode", "Exit Game"},
+		MenuOptions:   buildMenuOptions(config),
 		LevelData:     generateLevelData(config),
 	}
 	if debugMode {
@@ -97,6 +116,24 @@ func NewGameModeManagerWithDebug(debugMode bool, config *GameConfig) *GameModeMa
 	return gmm
 }
 
+// Main menu entries; each is also the localization key of its label
+const (
+	menuOptionNormal   = "menu.option.normal"
+	menuOptionEndless  = "menu.option.endless"
+	menuOptionSandbox  = "menu.option.sandbox"
+	menuOptionControls = "menu.option.controls"
+	menuOptionExit     = "menu.option.exit"
+)
+
+// buildMenuOptions lists the main menu entries; sandbox mode is only offered with god mode enabled
+func buildMenuOptions(config *GameConfig) []string {
+	options := []string{menuOptionNormal, menuOptionEndless}
+	if config != nil && config.GodMode {
+		options = append(options, menuOptionSandbox)
+	}
+	return append(options, menuOptionControls, menuOptionExit)
+}
+
 // generateLevelData creates the campaign levels for normal mode
 func generateLevelData(config *GameConfig) []LevelData {
 	levels := make([]LevelData, 10)
@@ -121,15 +158,15 @@ func generateLevelData(config *GameConfig) []LevelData {
 		difficultyMultiplier := 1.0 + float64(i)*0.3
 
 		levels[i] = LevelData{
-			LevelNumber:   level,
-			EnemyCount:    baseEnemyCount + i*2,
-			EnemyHealth:   int(float64(baseHealth) * difficultyMultiplier),
-			EnemySpeed:    baseSpeed + float64(i)*0.1,
-			SpawnDelay:    baseSpawnDelay - float64(i)*0.1,
-			StartingMoney: baseMoney + i*25,
-			WaveBonus:     75 + i*25,
-			RequiredKills: baseEnemyCount + i*2,
-			Description:   generateLevelDescription(level),
+			LevelNumber:    level,
+			EnemyCount:     baseEnemyCount + i*2,
+			EnemyHealth:    int(float64(baseHealth) * difficultyMultiplier),
+			EnemySpeed:     baseSpeed + float64(i)*0.1,
+			SpawnDelay:     baseSpawnDelay - float64(i)*0.1,
+			StartingMoney:  baseMoney + i*25,
+			WaveBonus:      75 + i*25,
+			RequiredKills:  baseEnemyCount + i*2,
+			DescriptionKey: levelDescriptionKey(level),
 		}
 
 		// Ensure minimum spawn delay
@@ -141,25 +178,12 @@ func generateLevelData(config *GameConfig) []LevelData {
 	return levels
 }
 
-// generateLevelDescription creates flavor text for each level
-func generateLevelDescription(level int) string {
-	descriptions := []string{
-		"Tutorial: Basic enemy forces approach your position.",
-		"Reinforcements: Enemy numbers are increasing.",
-		"Advanced Scouts: Faster and tougher enemies detected.",
-		"Heavy Assault: Armored units joining the attack.",
-		"Coordinated Strike: Multiple enemy waves incoming.",
-		"Elite Forces: Highly trained enemies with advanced gear.",
-		"Siege Warfare: Massive enemy army mobilizing.",
-		"Final Push: Enemy commander leads the assault.",
-		"Last Stand: Overwhelming enemy forces converge.",
-		"Ultimate Battle: Face the enemy's most powerful units.",
+// levelDescriptionKey returns the localization key of each level's flavor text
+func levelDescriptionKey(level int) string {
+	if level >= 1 && level <= 10 {
+		return fmt.Sprintf("level.description.%d", level)
 	}
-
-	if level <= len(descriptions) {
-		return descriptions[level-1]
-	}
-	return "Unknown threat level detected."
+	return "level.description.unknown"
 }
 
 // Update handles game mode logic updates
@@ -175,66 +199,48 @@ func (gmm *GameModeManager) Update(game *Game) error {
 		return gmm.updateVictory(game)
 	case StatePaused:
 		return gmm.updatePaused(game)
+	case StateControls:
+		return gmm.updateControls(game)
 	}
 	return nil
 }
 
 // updateMenu handles menu navigation and mode selection
 func (gmm *GameModeManager) updateMenu(game *Game) error {
-	// Handle menu navigation with proper key state management
-	upPressed := ebiten.IsKeyPressed(ebiten.KeyUp) || ebiten.IsKeyPressed(ebiten.KeyW)
-	downPressed := ebiten.IsKeyPressed(ebiten.KeyDown) || ebiten.IsKeyPressed(ebiten.KeyS)
-	enterPressed := ebiten.IsKeyPressed(ebiten.KeyEnter)
-	spacePressed := ebiten.IsKeyPressed(ebiten.KeySpace)
-
 	// Handle up navigation (only on key press, not hold)
-	if upPressed && !gmm.KeyUpPressed {
+	if game.input.JustPressed(ActionMenuUp) {
 		if gmm.MenuSelection > 0 {
 			gmm.MenuSelection--
 		}
 	}
-	gmm.KeyUpPressed = upPressed
 
 	// Handle down navigation (only on key press, not hold)
-	if downPressed && !gmm.KeyDownPressed {
+	if game.input.JustPressed(ActionMenuDown) {
 		if gmm.MenuSelection < len(gmm.MenuOptions)-1 {
 			gmm.MenuSelection++
 		}
 	}
-	gmm.KeyDownPressed = downPressed
-
-	// Handle mouse navigation
-	mouseX, mouseY := ebiten.CursorPosition()
-	menuY := 200
-	for i := 0; i < len(gmm.MenuOptions); i++ {
-		optionY := menuY + i*50
-		if mouseX >= game.config.WindowWidth/2-100 && mouseX <= game.config.WindowWidth/2+100 &&
-			mouseY >= optionY-10 && mouseY <= optionY+30 {
-			gmm.MenuSelection = i
-			break
-		}
-	}
-
-	// Handle menu selection (only on key press, not hold)
-	selectionMade := false
-	if (enterPressed && !gmm.KeyEnterPressed) || (spacePressed && !gmm.KeySpacePressed) {
-		selectionMade = true
-	}
-	gmm.KeyEnterPressed = enterPressed
-	gmm.KeySpacePressed = spacePressed
 
-	// Handle mouse click selection
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+	// Handle menu selection; mouse clicks come from the menu list. The confirm press is consumed
+	// so it doesn't also act in the mode it starts (e.g. Space sending a wave, A placing a tower).
+	selectionMade := gmm.MenuClicked
+	gmm.MenuClicked = false
+	if game.input.JustPressed(ActionConfirm) {
+		game.input.Consume(ActionConfirm)
 		selectionMade = true
 	}
 
 	if selectionMade {
-		switch gmm.MenuSelection {
-		case 0: // Normal Mode
+		switch gmm.MenuOptions[gmm.MenuSelection] {
+		case menuOptionNormal:
 			gmm.startNormalMode(game)
-		case 1: // Endless Mode
+		case menuOptionEndless:
 			gmm.startEndlessMode(game)
-		case 2: // Exit Game
+		case menuOptionSandbox:
+			gmm.startSandboxMode(game)
+		case menuOptionControls:
+			gmm.openControls(game)
+		case menuOptionExit:
 			return fmt.Errorf("game exit requested")
 		}
 	}
@@ -245,10 +251,8 @@ func (gmm *GameModeManager) updateMenu(game *Game) error {
 // updatePlaying handles gameplay state updates
 func (gmm *GameModeManager) updatePlaying(game *Game) error {
 	// Handle pause with proper key state management
-	escPressed := ebiten.IsKeyPressed(ebiten.KeyEscape)
-	pPressed := ebiten.IsKeyPressed(ebiten.KeyP)
-
-	if (escPressed || pPressed) && !(gmm.KeyUpPressed || gmm.KeyDownPressed) {
+	if game.input.JustPressed(ActionPause) {
+		game.input.Consume(ActionPause)
 		gmm.CurrentState = StatePaused
 		return nil
 	}
@@ -280,6 +284,8 @@ func (gmm *GameModeManager) updatePlaying(game *Game) error {
 		return gmm.updateNormalMode(game)
 	case GameModeEndless:
 		return gmm.updateEndlessMode(game)
+	case GameModeSandbox:
+		return gmm.updateSandboxMode(game)
 	}
 
 	return nil
@@ -336,12 +342,11 @@ func (gmm *GameModeManager) updateNormalMode(game *Game) error {
 			if bonus > 0 {
 				game.money += bonus
 				game.lastBonusEarned = bonus
+				gmm.LastSummary.EarlyBonus = bonus
 				game.bonusDisplayTimer = 3.0 // Show bonus for 3 seconds
 
-				// Create celebratory particle effects
-				centerX := float64(game.config.WindowWidth) / 2
-				centerY := float64(game.config.WindowHeight) / 2
-				game.graphics.CreateExplosion(Point{X: centerX, Y: centerY}, 5, game.config)
+				// Create celebratory particle effects in the middle of the view
+				game.graphics.EmitEffect("game", effectBonus, Point{X: game.camera.X, Y: game.camera.Y}, Point{}, game.config)
 
 				if game.config.DebugMode {
 					fmt.Printf("*** EARLY COMPLETION BONUS: $%d ***\n", bonus)
@@ -365,57 +370,43 @@ func (gmm *GameModeManager) updateEndlessMode(game *Game) error {
 		gmm.EndlessWave++
 		gmm.EndlessDifficulty += 0.15 // Increase difficulty by 15% each wave
 		gmm.setupEndlessWave(game)
+		gmm.ShowLevelInfo = true
+		gmm.LevelInfoTimer = 1.0
 	}
 	return nil
 }
 
 // updateGameOver handles game over state
 func (gmm *GameModeManager) updateGameOver(game *Game) error {
-	enterPressed := ebiten.IsKeyPressed(ebiten.KeyEnter)
-	spacePressed := ebiten.IsKeyPressed(ebiten.KeySpace)
-	rPressed := ebiten.IsKeyPressed(ebiten.KeyR)
-
-	if (enterPressed && !gmm.KeyEnterPressed) || (spacePressed && !gmm.KeySpacePressed) {
+	if game.input.JustPressed(ActionConfirm) {
+		game.input.Consume(ActionConfirm)
 		gmm.returnToMenu(game)
-	}
-	if rPressed {
+	} else if game.input.JustPressed(ActionRestart) {
+		game.input.Consume(ActionRestart)
 		gmm.restartCurrentMode(game)
 	}
-
-	gmm.KeyEnterPressed = enterPressed
-	gmm.KeySpacePressed = spacePressed
 	return nil
 }
 
 // updateVictory handles victory state (normal mode completion)
 func (gmm *GameModeManager) updateVictory(game *Game) error {
-	enterPressed := ebiten.IsKeyPressed(ebiten.KeyEnter)
-	spacePressed := ebiten.IsKeyPressed(ebiten.KeySpace)
-	rPressed := ebiten.IsKeyPressed(ebiten.KeyR)
-
-	if (enterPressed && !gmm.KeyEnterPressed) || (spacePressed && !gmm.KeySpacePressed) {
+	if game.input.JustPressed(ActionConfirm) {
+		game.input.Consume(ActionConfirm)
 		gmm.returnToMenu(game)
-	}
-	if rPressed {
+	} else if game.input.JustPressed(ActionRestart) {
+		game.input.Consume(ActionRestart)
 		gmm.startNormalMode(game) // Restart campaign
 	}
-
-	gmm.KeyEnterPressed = enterPressed
-	gmm.KeySpacePressed = spacePressed
 	return nil
 }
 
 // updatePaused handles pause state
 func (gmm *GameModeManager) updatePaused(game *Game) error {
-	// Handle resume with key state management
-	escPressed := ebiten.IsKeyPressed(ebiten.KeyEscape)
-	pPressed := ebiten.IsKeyPressed(ebiten.KeyP)
-	mPressed := ebiten.IsKeyPressed(ebiten.KeyM)
-
-	if (escPressed || pPressed) && !(gmm.KeyUpPressed || gmm.KeyDownPressed) {
+	if game.input.JustPressed(ActionPause) {
+		game.input.Consume(ActionPause)
 		gmm.CurrentState = StatePlaying
-	}
-	if mPressed && !gmm.KeyEnterPressed {
+	} else if game.input.JustPressed(ActionMenu) {
+		game.input.Consume(ActionMenu)
 		gmm.returnToMenu(game)
 	}
 	return nil
@@ -426,6 +417,7 @@ func (gmm *GameModeManager) startNormalMode(game *Game) {
 	gmm.CurrentMode = GameModeNormal
 	gmm.CurrentState = StatePlaying
 	gmm.CurrentLevel = 1
+	gmm.LastSummary = WaveSummary{}
 	gmm.setupLevel(game, 1)
 	gmm.ShowLevelInfo = true
 	gmm.LevelInfoTimer = 1.0
@@ -437,11 +429,21 @@ func (gmm *GameModeManager) startEndlessMode(game *Game) {
 	gmm.CurrentState = StatePlaying
 	gmm.EndlessWave = 1
 	gmm.EndlessDifficulty = 1.0
+	gmm.LastSummary = WaveSummary{}
 	gmm.setupEndlessWave(game)
 	gmm.ShowLevelInfo = true
 	gmm.LevelInfoTimer = 1.0
 }
 
+// startSandboxMode initializes sandbox mode for balance testing
+func (gmm *GameModeManager) startSandboxMode(game *Game) {
+	gmm.CurrentMode = GameModeSandbox
+	gmm.CurrentState = StatePlaying
+	gmm.SandboxHealth = game.config.BaseEnemyHealth
+	gmm.SandboxSpeed = game.config.EnemySpeed
+	gmm.setupSandbox(game)
+}
+
 // setupLevel configures the game for a specific campaign level
 func (gmm *GameModeManager) setupLevel(game *Game, level int) {
 	if level > len(gmm.LevelData) {
@@ -470,6 +472,14 @@ func (gmm *GameModeManager) setupLevel(game *Game, level int) {
 
 // setupEndlessWave configures the game for the next endless wave
 func (gmm *GameModeManager) setupEndlessWave(game *Game) {
+	// Pay interest on money banked through the previous wave, plus bank income
+	interest := 0
+	bankIncome := 0
+	if gmm.EndlessWave > 1 {
+		interest = game.config.CalculateInterest(game.money)
+		bankIncome = game.bankIncome()
+	}
+
 	// Reset enemies and projectiles but keep towers and money
 	game.enemies = []*Enemy{}
 	game.projectiles = []*Projectile{}
@@ -495,8 +505,13 @@ func (gmm *GameModeManager) setupEndlessWave(game *Game) {
 
 	// Bonus money for surviving longer
 	waveBonus := 50 + gmm.EndlessWave*10
-	game.money += waveBonus
+	game.money += waveBonus + interest + bankIncome
 	game.config.WaveBonus = waveBonus
+
+	game.lastInterestEarned = interest
+	if gmm.EndlessWave > 1 {
+		gmm.LastSummary = WaveSummary{Wave: gmm.EndlessWave - 1, WaveBonus: waveBonus, Interest: interest, BankIncome: bankIncome}
+	}
 }
 
 // advanceLevel moves to the next campaign level
@@ -508,9 +523,19 @@ func (gmm *GameModeManager) advanceLevel(game *Game) {
 	}
 
 	oldLevel := gmm.CurrentLevel
+	// Interest is earned on the money banked through the level, before setupLevel replaces it
+	// with the new level's starting money
+	interest := game.config.CalculateInterest(game.money)
+	bankIncome := game.bankIncome()
+
 	gmm.CurrentLevel++
 	game.money += gmm.LevelData[gmm.CurrentLevel-2].WaveBonus // Previous level bonus
 	gmm.setupLevel(game, gmm.CurrentLevel)
+
+	// Interest and bank income are paid on top of the new level's starting money
+	game.money += interest + bankIncome
+	game.lastInterestEarned = interest
+	gmm.LastSummary = WaveSummary{Wave: oldLevel, Interest: interest, BankIncome: bankIncome}
 	gmm.ShowLevelInfo = true
 	gmm.LevelInfoTimer = 1.0
 
@@ -584,21 +609,21 @@ func (gmm *GameModeManager) returnToMenu(game *Game) {
 	gmm.CurrentState = StateMenu
 	gmm.MenuSelection = 0
 
-	// Reset key states
-	gmm.KeyUpPressed = false
-	gmm.KeyDownPressed = false
-	gmm.KeyEnterPressed = false
-	gmm.KeySpacePressed = false
-
 	// Reset game state
 	game.enemies = []*Enemy{}
 	game.towers = []*Tower{}
+	game.selectedTower = nil
 	game.projectiles = []*Projectile{}
 	game.money = game.config.StartingMoney
 	game.lives = game.config.StartingLives
 	game.wave = 1
 	game.enemiesSpawned = 0
 	game.gameOver = false
+	game.gameSpeed = 1.0
+	game.tickAccumulator = 0
+	game.lastInterestEarned = 0
+	game.camera.Reset()
+	game.graphics.ParticleSystem.Clear()
 }
 
 // restartCurrentMode restarts the current game mode
@@ -608,220 +633,140 @@ func (gmm *GameModeManager) restartCurrentMode(game *Game) {
 		gmm.startNormalMode(game)
 	case GameModeEndless:
 		gmm.startEndlessMode(game)
+	case GameModeSandbox:
+		gmm.startSandboxMode(game)
 	}
 }
 
-// DrawMenu renders the main menu
-func (gmm *GameModeManager) DrawMenu(screen *ebiten.Image, config *GameConfig) {
-	// Clear screen with dark background
-	screen.Fill(color.RGBA{20, 30, 40, 255})
-
-	// Title
-	titleText := "TOWER DEFENSE"
-	ebitenutil.DebugPrintAt(screen, titleText, config.WindowWidth/2-100, 100)
-
-	// Subtitle
-	subtitleText := "Choose Your Battle Mode"
-	ebitenutil.DebugPrintAt(screen, subtitleText, config.WindowWidth/2-80, 140)
-
-	// Menu options
-	menuY := 200
-	for i, option := range gmm.MenuOptions {
-		x := config.WindowWidth/2 - 80
-		y := menuY + i*50
-
-		// Highlight selected option
-		if i == gmm.MenuSelection {
-			// Draw selection background
-			vector.DrawFilledRect(screen, float32(x-20), float32(y-5), 220, 30, color.RGBA{50, 100, 150, 150}, false)
-			vector.StrokeRect(screen, float32(x-20), float32(y-5), 220, 30, 2, color.RGBA{100, 150, 200, 255}, false)
-			optionText := "► " + option + " ◄"
-			ebitenutil.DebugPrintAt(screen, optionText, x-5, y)
-		} else {
-			ebitenutil.DebugPrintAt(screen, option, x, y)
-		}
-	}
-
-	// Mode descriptions
-	descY := menuY + len(gmm.MenuOptions)*50 + 40
-	switch gmm.MenuSelection {
-	case 0: // Normal Mode
-		desc := "Campaign Mode: Complete 10 progressively challenging levels\nEach level has unique objectives and difficulty scaling\nComplete all levels to achieve victory!"
-		ebitenutil.DebugPrintAt(screen, desc, 50, descY)
-	case 1: // Endless Mode
-		desc := "Endless Mode: Survive infinite waves of enemies\nDifficulty increases with each wave\nHow long can you survive?"
-		ebitenutil.DebugPrintAt(screen, desc, 50, descY)
-	case 2: // Exit
-		desc := "Exit the game"
-		ebitenutil.DebugPrintAt(screen, desc, 50, descY)
-	}
-
-	// Show current selection clearly
-	selectionIndicator := fmt.Sprintf("Selected: %s (Press ENTER/SPACE or Click to confirm)", gmm.MenuOptions[gmm.MenuSelection])
-	ebitenutil.DebugPrintAt(screen, selectionIndicator, 50, config.WindowHeight-80)
+// DrawMenu renders the main menu background; the menu widgets are drawn by the UI layer
+func (gmm *GameModeManager) DrawMenu(screen *ebiten.Image, game *Game) {
+	screen.Fill(game.theme.Background)
+}
 
-	// Controls
-	controlsText := "Controls: ↑/↓ Navigate | ENTER/SPACE Select | ESC Exit"
-	ebitenutil.DebugPrintAt(screen, controlsText, 50, config.WindowHeight-50)
+// menuDescription explains the highlighted menu option
+func (gmm *GameModeManager) menuDescription(game *Game) string {
+	option := gmm.MenuOptions[gmm.MenuSelection]
+	return game.loc.T(strings.Replace(option, "menu.option.", "menu.description.", 1))
 }
 
-// DrawGameState renders game state overlays
+// DrawGameState renders the screen tints over the map
 func (gmm *GameModeManager) DrawGameState(screen *ebiten.Image, game *Game) {
-	config := game.config
+	width := float32(game.screenWidth)
+	height := float32(game.screenHeight)
 
 	switch gmm.CurrentState {
 	case StatePlaying:
-		gmm.drawPlayingOverlay(screen, game)
+		// Dim the field while the level info is shown
+		if gmm.ShowLevelInfo {
+			vector.DrawFilledRect(screen, 0, 0, width, height, game.theme.OverlayLevelInfo, false)
+		}
 	case StateGameOver:
-		gmm.drawGameOverScreen(screen, config)
+		vector.DrawFilledRect(screen, 0, 0, width, height, game.theme.OverlayGameOver, false)
 	case StateVictory:
-		gmm.drawVictoryScreen(screen, config)
+		vector.DrawFilledRect(screen, 0, 0, width, height, game.theme.OverlayVictory, false)
 	case StatePaused:
-		gmm.drawPausedOverlay(screen, config)
+		// Light tint and a border instead of blacking out the field
+		vector.DrawFilledRect(screen, 0, 0, width, height, game.theme.OverlayPaused, false)
+		vector.StrokeRect(screen, 2, 2, width-4, height-4, 4, game.theme.OverlayPausedBorder, false)
 	}
 }
 
-// drawPlayingOverlay renders gameplay UI overlays
-func (gmm *GameModeManager) drawPlayingOverlay(screen *ebiten.Image, game *Game) {
-	// Mode-specific UI
+// modeInfoText describes the progress of the current mode
+func (gmm *GameModeManager) modeInfoText(game *Game) string {
+	loc := game.loc
 	switch gmm.CurrentMode {
 	case GameModeNormal:
-		modeText := fmt.Sprintf("CAMPAIGN MODE - Level %d/%d", gmm.CurrentLevel, gmm.MaxLevel)
-		ebitenutil.DebugPrintAt(screen, modeText, 10, game.config.WindowHeight-100)
-
+		text := loc.T("mode.campaign", gmm.CurrentLevel, gmm.MaxLevel)
 		if gmm.CurrentLevel <= len(gmm.LevelData) {
 			levelData := gmm.LevelData[gmm.CurrentLevel-1]
-			progressText := fmt.Sprintf("Progress: %d/%d enemies",
+			text += "\n" + loc.N("mode.progress", levelData.EnemyCount,
 				maxInt(0, levelData.EnemyCount-len(game.enemies)), levelData.EnemyCount)
-			ebitenutil.DebugPrintAt(screen, progressText, 10, game.config.WindowHeight-80)
 		}
+		return text
 
 	case GameModeEndless:
-		modeText := fmt.Sprintf("ENDLESS MODE - Wave %d", gmm.EndlessWave)
-		ebitenutil.DebugPrintAt(screen, modeText, 10, game.config.WindowHeight-100)
+		return loc.T("mode.endless", gmm.EndlessWave, gmm.EndlessDifficulty)
 
-		difficultyText := fmt.Sprintf("Difficulty: %.1fx", gmm.EndlessDifficulty)
-		ebitenutil.DebugPrintAt(screen, difficultyText, 10, game.config.WindowHeight-80)
+	case GameModeSandbox:
+		return gmm.sandboxInfoText(game)
 	}
+	return ""
+}
 
-	// Show level info at start of level
-	if gmm.ShowLevelInfo {
-		gmm.drawLevelInfo(screen, game)
+// levelInfoTitle names the level or wave being introduced
+func (gmm *GameModeManager) levelInfoTitle(game *Game) string {
+	switch gmm.CurrentMode {
+	case GameModeNormal:
+		return game.loc.T("level.title", gmm.CurrentLevel)
+	case GameModeEndless:
+		return game.loc.T("endless.title", gmm.EndlessWave)
 	}
-
-	// Game controls
-	controlsText := "ESC/P: Pause | M: Menu"
-	ebitenutil.DebugPrintAt(screen, controlsText, 10, game.config.WindowHeight-40)
+	return ""
 }
 
-// drawLevelInfo shows level information at the start
-func (gmm *GameModeManager) drawLevelInfo(screen *ebiten.Image, game *Game) {
-	// Semi-transparent overlay
-	vector.DrawFilledRect(screen, 0, 0, float32(game.config.WindowWidth), float32(game.config.WindowHeight),
-		color.RGBA{0, 0, 0, 150}, false)
-
-	centerX := game.config.WindowWidth / 2
-	centerY := game.config.WindowHeight / 2
-
+// levelInfoText describes the level or wave being introduced
+func (gmm *GameModeManager) levelInfoText(game *Game) string {
+	loc := game.loc
 	switch gmm.CurrentMode {
 	case GameModeNormal:
 		if gmm.CurrentLevel <= len(gmm.LevelData) {
 			levelData := gmm.LevelData[gmm.CurrentLevel-1]
-
-			titleText := fmt.Sprintf("LEVEL %d", gmm.CurrentLevel)
-			ebitenutil.DebugPrintAt(screen, titleText, centerX-50, centerY-80)
-
-			ebitenutil.DebugPrintAt(screen, levelData.Description, centerX-150, centerY-40)
-
-			statsText := fmt.Sprintf("Enemies: %d | Health: %d | Speed: %.1fx",
-				levelData.EnemyCount, levelData.EnemyHealth, levelData.EnemySpeed)
-			ebitenutil.DebugPrintAt(screen, statsText, centerX-120, centerY)
-
-			bonusText := fmt.Sprintf("Starting Money: $%d | Wave Bonus: $%d",
-				levelData.StartingMoney, levelData.WaveBonus)
-			ebitenutil.DebugPrintAt(screen, bonusText, centerX-120, centerY+20)
+			return loc.T(levelData.DescriptionKey) + "\n\n" + loc.T("level.stats",
+				levelData.EnemyCount, levelData.EnemyHealth, levelData.EnemySpeed,
+				loc.Money(levelData.StartingMoney), loc.Money(levelData.WaveBonus))
 		}
 
 	case GameModeEndless:
-		titleText := fmt.Sprintf("WAVE %d", gmm.EndlessWave)
-		ebitenutil.DebugPrintAt(screen, titleText, centerX-50, centerY-60)
-
 		if gmm.EndlessWave == 1 {
-			descText := "Endless Mode: Survive as long as possible!"
-			ebitenutil.DebugPrintAt(screen, descText, centerX-120, centerY-20)
-		} else {
-			diffText := fmt.Sprintf("Difficulty increased to %.1fx", gmm.EndlessDifficulty)
-			ebitenutil.DebugPrintAt(screen, diffText, centerX-100, centerY-20)
+			return loc.T("endless.intro")
 		}
+		return loc.T("endless.difficulty", gmm.EndlessDifficulty)
 	}
+	return ""
+}
 
+// levelInfoCountdown shows how long the level info stays up
+func (gmm *GameModeManager) levelInfoCountdown(game *Game) string {
 	if gmm.LevelInfoTimer > 0.5 {
-		countdownText := fmt.Sprintf("Starting in %.1f seconds...", gmm.LevelInfoTimer)
-		ebitenutil.DebugPrintAt(screen, countdownText, centerX-80, centerY+60)
-	} else {
-		readyText := "Ready! Game starting..."
-		ebitenutil.DebugPrintAt(screen, readyText, centerX-80, centerY+60)
+		return game.loc.T("level.countdown", gmm.LevelInfoTimer)
 	}
+	return game.loc.T("level.ready")
 }
 
-// drawGameOverScreen renders game over screen
-func (gmm *GameModeManager) drawGameOverScreen(screen *ebiten.Image, config *GameConfig) {
-	// Semi-transparent overlay
-	vector.DrawFilledRect(screen, 0, 0, float32(config.WindowWidth), float32(config.WindowHeight),
-		color.RGBA{0, 0, 0, 200}, false)
-
-	centerX := config.WindowWidth / 2
-	centerY := config.WindowHeight / 2
+// waveSummaryText is the post-wave summary of what the previous wave paid out
+func (gmm *GameModeManager) waveSummaryText(game *Game) string {
+	if gmm.LastSummary.Wave == 0 {
+		return ""
+	}
 
-	titleText := "GAME OVER"
-	ebitenutil.DebugPrintAt(screen, titleText, centerX-50, centerY-60)
+	loc := game.loc
+	summary := gmm.LastSummary
+	summaryText := loc.T("summary.cleared", summary.Wave)
+	if summary.WaveBonus > 0 {
+		summaryText += loc.T("summary.bonus", loc.Money(summary.WaveBonus))
+	}
+	if summary.EarlyBonus > 0 {
+		summaryText += loc.T("summary.early", loc.Money(summary.EarlyBonus))
+	}
+	if game.paysInterest() {
+		summaryText += loc.T("summary.interest", loc.Money(summary.Interest))
+	}
+	if summary.BankIncome > 0 {
+		summaryText += loc.T("summary.banks", loc.Money(summary.BankIncome))
+	}
+	return summaryText
+}
 
-	var statsText string
+// gameOverText summarizes how far the player got
+func (gmm *GameModeManager) gameOverText(game *Game) string {
 	switch gmm.CurrentMode {
 	case GameModeNormal:
-		statsText = fmt.Sprintf("Reached Level: %d/%d", gmm.CurrentLevel, gmm.MaxLevel)
+		return game.loc.T("gameover.level", gmm.CurrentLevel, gmm.MaxLevel)
 	case GameModeEndless:
-		statsText = fmt.Sprintf("Survived Waves: %d", gmm.EndlessWave-1)
+		survived := gmm.EndlessWave - 1
+		return game.loc.N("gameover.waves", survived, survived)
 	}
-	ebitenutil.DebugPrintAt(screen, statsText, centerX-80, centerY-20)
-
-	controlsText := "ENTER: Return to Menu | R: Restart"
-	ebitenutil.DebugPrintAt(screen, controlsText, centerX-120, centerY+20)
-}
-
-// drawVictoryScreen renders victory screen (normal mode completion)
-func (gmm *GameModeManager) drawVictoryScreen(screen *ebiten.Image, config *GameConfig) {
-	// Semi-transparent overlay
-	vector.DrawFilledRect(screen, 0, 0, float32(config.WindowWidth), float32(config.WindowHeight),
-		color.RGBA{0, 100, 0, 200}, false)
-
-	centerX := config.WindowWidth / 2
-	centerY := config.WindowHeight / 2
-
-	titleText := "VICTORY!"
-	ebitenutil.DebugPrintAt(screen, titleText, centerX-40, centerY-60)
-
-	congratsText := "Campaign Completed Successfully!"
-	ebitenutil.DebugPrintAt(screen, congratsText, centerX-120, centerY-20)
-
-	controlsText := "ENTER: Return to Menu | R: Play Again"
-	ebitenutil.DebugPrintAt(screen, controlsText, centerX-120, centerY+20)
-}
-
-// drawPausedOverlay renders pause screen
-func (gmm *GameModeManager) drawPausedOverlay(screen *ebiten.Image, config *GameConfig) {
-	// Semi-transparent overlay
-	vector.DrawFilledRect(screen, 0, 0, float32(config.WindowWidth), float32(config.WindowHeight),
-		color.RGBA{0, 0, 0, 150}, false)
-
-	centerX := config.WindowWidth / 2
-	centerY := config.WindowHeight / 2
-
-	titleText := "PAUSED"
-	ebitenutil.DebugPrintAt(screen, titleText, centerX-30, centerY-20)
-
-	controlsText := "ESC/P: Resume | M: Return to Menu"
-	ebitenutil.DebugPrintAt(screen, controlsText, centerX-100, centerY+20)
+	return ""
 }
 
 // GetCurrentModeInfo returns information about the current game mode
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestAdvanceLevelPaysInterest(t *testing.T) {
+	config := DefaultConfig()
+	config.InterestEnabled = true
+	config.InterestRate = 10
+	config.InterestCap = 50
+	game := NewGame(config)
+	gmm := game.modeManager
+	gmm.startNormalMode(game)
+
+	game.money = 300
+	gmm.advanceLevel(game)
+
+	want := gmm.LevelData[1].StartingMoney + 30
+	if game.money != want {
+		t.Errorf("money after level 1: got %d, want the starting money of level 2 plus 30 interest (%d)", game.money, want)
+	}
+	if gmm.LastSummary.Interest != 30 || game.lastInterestEarned != 30 {
+		t.Errorf("interest shown: summary %d, HUD %d, want 30", gmm.LastSummary.Interest, game.lastInterestEarned)
+	}
+
+	game.money = 5000
+	gmm.advanceLevel(game)
+	if gmm.LastSummary.Interest != config.InterestCap {
+		t.Errorf("interest on a large balance: got %d, want the cap %d", gmm.LastSummary.Interest, config.InterestCap)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Holding a direction waits this many repeat intervals before the cursor starts repeating
+const gamepadCursorInitialDelay = 3
+
+// handleGamepadInput moves the grid cursor and handles placing, selecting and cycling tower
+// types from a gamepad; moving the mouse hands placement back to the mouse
+func (g *Game) handleGamepadInput() {
+	if g.ui.Moved {
+		g.usingGamepad = false
+	}
+	if !g.input.GamepadConnected() {
+		g.usingGamepad = false
+		return
+	}
+
+	// Move the cursor one cell per press, repeating while held
+	dx, dy := 0, 0
+	if g.input.Held(ActionCursorLeft) {
+		dx--
+	}
+	if g.input.Held(ActionCursorRight) {
+		dx++
+	}
+	if g.input.Held(ActionCursorUp) {
+		dy--
+	}
+	if g.input.Held(ActionCursorDown) {
+		dy++
+	}
+	if dx != 0 || dy != 0 {
+		g.cursorRepeatTimer -= 1.0 / 60.0
+		if !g.cursorHeld || g.cursorRepeatTimer <= 0 {
+			if g.usingGamepad {
+				g.moveGamepadCursor(dx, dy)
+			}
+			g.usingGamepad = true
+
+			g.cursorRepeatTimer = g.config.GamepadCursorRepeat
+			if !g.cursorHeld {
+				g.cursorRepeatTimer *= gamepadCursorInitialDelay
+			}
+		}
+		g.cursorHeld = true
+	} else {
+		g.cursorHeld = false
+	}
+
+	// Cycle through tower types
+	if g.input.JustPressed(ActionPrevTowerType) {
+		g.selectedTowerType = (g.selectedTowerType+towerTypeCount-2)%towerTypeCount + 1
+	}
+	if g.input.JustPressed(ActionNextTowerType) {
+		g.selectedTowerType = g.selectedTowerType%towerTypeCount + 1
+	}
+
+	// Select the tower under the cursor or place a new one
+	if g.input.JustPressed(ActionPlace) {
+		g.input.Consume(ActionPlace)
+		g.usingGamepad = true
+		gridX, gridY := g.gamepadCursor.X, g.gamepadCursor.Y
+		if tower := g.towerAt(gridX, gridY); tower != nil {
+			g.selectedTower = tower
+		} else {
+			g.placeTower(gridX, gridY)
+		}
+	}
+}
+
+// moveGamepadCursor steps the grid cursor, keeping it on the map and in view
+func (g *Game) moveGamepadCursor(dx, dy int) {
+	mapWidth, mapHeight := g.config.MapSize()
+	g.gamepadCursor.X = math.Max(0, math.Min(g.gamepadCursor.X+float64(dx), float64(mapWidth-1)))
+	g.gamepadCursor.Y = math.Max(0, math.Min(g.gamepadCursor.Y+float64(dy), float64(mapHeight-1)))
+
+	// Keep the cursor cell, plus one cell around it, in view
+	cellSize := float64(g.config.GridSize)
+	g.camera.Follow(g.gamepadCursor.X*cellSize+cellSize/2, g.gamepadCursor.Y*cellSize+cellSize/2, cellSize*1.5*g.camera.Scale())
+}
+
+// submitGamepadCursor outlines the cell under the gamepad cursor, over everything on the map
+func (g *Game) submitGamepadCursor(queue *RenderQueue) {
+	if !g.usingGamepad {
+		return
+	}
+	cellSize := float32(g.config.GridSize)
+	x := float32(g.gamepadCursor.X) * cellSize
+	y := float32(g.gamepadCursor.Y) * cellSize
+	queue.Submit(LayerOverlays, float64(y+cellSize), func(screen *ebiten.Image) {
+		vector.StrokeRect(screen, x+1, y+1, cellSize-2, cellSize-2, 2, g.theme.GamepadCursor, false)
+	})
+}
@@ -3,39 +3,78 @@ package main
 import (
 	"image/color"
 	"math"
-	"math/rand"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
-// Sprite represents a drawable game object with animations
+// Sprite describes how a kind of game object is drawn and animated; it is shared by every
+// object of that kind, and each object keeps its own AnimState. Image and Animations are set
+// when a sprite sheet is loaded (see assets.go), otherwise the object is drawn with vectors.
 type Sprite struct {
-	Image        *ebiten.Image
-	Width        int
-	Height       int
-	FrameCount   int
-	CurrentFrame int
-	AnimSpeed    float64
-	AnimTimer    float64
+	Image      *ebiten.Image
+	Width      int
+	Height     int
+	FrameCount int
+	AnimSpeed  float64 // Seconds per frame
+	Animations map[string]*Animation
+	AnchorX    float64 // Point of a frame drawn at the object's position (0-1)
+	AnchorY    float64
+	Rotate     bool // Frames are turned to the object's facing
 }
 
-// Particle represents a visual effect particle
-type Particle struct {
-	Position Point
-	Velocity Point
-	Life     float64
-	MaxLife  float64
-	Color    color.RGBA
-	Size     float32
-	Gravity  float64
-	FadeOut  bool
-	Active   bool
+// AnimState is the animation progress of a single tower or enemy
+type AnimState struct {
+	Frame int
+	Timer float64
+
+	// A sprite sheet animation playing once over the looping one, "" when none
+	OneShot      string
+	OneShotFrame int
+	OneShotTimer float64
 }
 
-// ParticleSystem manages multiple particles
-type ParticleSystem struct {
-	Particles []*Particle
+// Advance moves the animation on by dt seconds, stepping through the sprite's frames
+func (a *AnimState) Advance(sprite *Sprite, dt float64) {
+	if sprite == nil || sprite.FrameCount <= 0 || sprite.AnimSpeed <= 0 {
+		return
+	}
+	a.Timer += dt
+	for a.Timer >= sprite.AnimSpeed {
+		a.Timer -= sprite.AnimSpeed
+		a.Frame = (a.Frame + 1) % sprite.FrameCount
+	}
+
+	if anim := sprite.Animations[a.OneShot]; anim != nil {
+		a.OneShotTimer += dt
+		for a.OneShotTimer >= anim.FrameTime {
+			a.OneShotTimer -= anim.FrameTime
+			a.OneShotFrame++
+			if a.OneShotFrame >= len(anim.Frames) {
+				a.OneShot = ""
+				break
+			}
+		}
+	}
+}
+
+// Play starts a one-shot animation from the sprite sheet; sprites without it are unaffected
+func (a *AnimState) Play(sprite *Sprite, name string) {
+	if sprite == nil || sprite.Animations[name] == nil {
+		return
+	}
+	a.OneShot = name
+	a.OneShotFrame = 0
+	a.OneShotTimer = 0
+}
+
+// current returns the animation to draw and its frame, given the object's looping animation
+func (a *AnimState) current(loop string) (string, int) {
+	if a.OneShot != "" {
+		return a.OneShot, a.OneShotFrame
+	}
+	return loop, a.Frame
 }
 
 // GraphicsManager handles all visual effects and sprites
@@ -44,14 +83,30 @@ type GraphicsManager struct {
 	TowerSprites   map[int]*Sprite
 	EnemySprite    *Sprite
 	Textures       map[string]*ebiten.Image
+	Emitters       map[string]*Emitter
+	Effects        map[string]map[string]string // Emitter name per entity and event, see emitters.go
+	Theme          *Theme
+	ghostImage     *ebiten.Image // Offscreen buffer for the placement preview
+
+	// Terrain rendered by DrawTexturedBackground, and the map it was rendered for
+	background     *ebiten.Image
+	backgroundGrid int
+	backgroundPath []Point
 }
 
-// NewGraphicsManager creates a new graphics manager
-func NewGraphicsManager() *GraphicsManager {
+// ghostImageSize is large enough to hold any tower including barrels
+const ghostImageSize = 80
+
+// NewGraphicsManager creates a graphics manager drawing with the colors of a theme and
+// showing at most maxParticles particles at once
+func NewGraphicsManager(theme *Theme, maxParticles int) *GraphicsManager {
 	gm := &GraphicsManager{
-		ParticleSystem: &ParticleSystem{Particles: []*Particle{}},
+		ParticleSystem: NewParticleSystem(maxParticles),
 		TowerSprites:   make(map[int]*Sprite),
 		Textures:       make(map[string]*ebiten.Image),
+		Emitters:       make(map[string]*Emitter),
+		Effects:        make(map[string]map[string]string),
+		Theme:          theme,
 	}
 
 	gm.initializeSprites()
@@ -62,7 +117,7 @@ func NewGraphicsManager() *GraphicsManager {
 // initializeSprites creates sprite data for game objects
 func (gm *GraphicsManager) initializeSprites() {
 	// Basic Tower Sprite (animated rotation)
-	gm.TowerSprites[1] = &Sprite{
+	gm.TowerSprites[TowerBasic] = &Sprite{
 		Width:      30,
 		Height:     30,
 		FrameCount: 8,
@@ -70,7 +125,7 @@ func (gm *GraphicsManager) initializeSprites() {
 	}
 
 	// Heavy Tower Sprite (pulsing animation)
-	gm.TowerSprites[2] = &Sprite{
+	gm.TowerSprites[TowerHeavy] = &Sprite{
 		Width:      35,
 		Height:     35,
 		FrameCount: 4,
@@ -78,7 +133,7 @@ func (gm *GraphicsManager) initializeSprites() {
 	}
 
 	// Sniper Tower Sprite (slow tracking animation)
-	gm.TowerSprites[3] = &Sprite{
+	gm.TowerSprites[TowerSniper] = &Sprite{
 		Width:      32,
 		Height:     32,
 		FrameCount: 12,
@@ -86,7 +141,7 @@ func (gm *GraphicsManager) initializeSprites() {
 	}
 
 	// Laser Tower Sprite (fast spinning animation)
-	gm.TowerSprites[4] = &Sprite{
+	gm.TowerSprites[TowerLaser] = &Sprite{
 		Width:      28,
 		Height:     28,
 		FrameCount: 16,
@@ -94,7 +149,7 @@ func (gm *GraphicsManager) initializeSprites() {
 	}
 
 	// Splash Tower Sprite (charging animation)
-	gm.TowerSprites[5] = &Sprite{
+	gm.TowerSprites[TowerSplash] = &Sprite{
 		Width:      38,
 		Height:     38,
 		FrameCount: 6,
@@ -102,13 +157,37 @@ func (gm *GraphicsManager) initializeSprites() {
 	}
 
 	// Slow Tower Sprite (wave animation)
-	gm.TowerSprites[6] = &Sprite{
+	gm.TowerSprites[TowerSlow] = &Sprite{
 		Width:      34,
 		Height:     34,
 		FrameCount: 8,
 		AnimSpeed:  0.25,
 	}
 
+	// Bank Sprite (coin glint animation)
+	gm.TowerSprites[TowerBank] = &Sprite{
+		Width:      32,
+		Height:     32,
+		FrameCount: 8,
+		AnimSpeed:  0.2,
+	}
+
+	// Radar Sprite (sweeping dish animation)
+	gm.TowerSprites[TowerRadar] = &Sprite{
+		Width:      32,
+		Height:     32,
+		FrameCount: 24,
+		AnimSpeed:  0.05,
+	}
+
+	// Armory Sprite (forge glow animation)
+	gm.TowerSprites[TowerArmory] = &Sprite{
+		Width:      34,
+		Height:     34,
+		FrameCount: 6,
+		AnimSpeed:  0.25,
+	}
+
 	// Enemy Sprite (walking animation)
 	gm.EnemySprite = &Sprite{
 		Width:      20,
@@ -132,15 +211,13 @@ func (gm *GraphicsManager) createGrassTexture(width, height int) *ebiten.Image {
 	img := ebiten.NewImage(width, height)
 
 	// Base grass color
-	baseColor := color.RGBA{34, 139, 34, 255}
-	img.Fill(baseColor)
+	img.Fill(gm.Theme.Grass)
 
 	// Add subtle grass texture without animation
 	for x := 0; x < width; x += 8 {
 		for y := 0; y < height; y += 8 {
 			if (x+y)%16 == 0 { // Create a subtle pattern
-				grassColor := color.RGBA{40, 150, 40, 255}
-				vector.DrawFilledRect(img, float32(x), float32(y), 2, 3, grassColor, false)
+				vector.DrawFilledRect(img, float32(x), float32(y), 2, 3, gm.Theme.GrassDetail, false)
 			}
 		}
 	}
@@ -152,16 +229,14 @@ func (gm *GraphicsManager) createGrassTexture(width, height int) *ebiten.Image {
 func (gm *GraphicsManager) createPathTexture(width, height int) *ebiten.Image {
 	img := ebiten.NewImage(width, height)
 
-	// Base path color (darker brown/gray)
-	baseColor := color.RGBA{101, 67, 33, 255}
-	img.Fill(baseColor)
+	// Base path color
+	img.Fill(gm.Theme.Path)
 
 	// Add static stone pattern
 	for x := 0; x < width; x += 12 {
 		for y := 0; y < height; y += 12 {
 			if (x*y)%144 < 48 { // Create consistent stone pattern
-				stoneColor := color.RGBA{85, 55, 25, 255}
-				vector.DrawFilledCircle(img, float32(x+4), float32(y+4), 2, stoneColor, false)
+				vector.DrawFilledCircle(img, float32(x+4), float32(y+4), 2, gm.Theme.PathStone, false)
 			}
 		}
 	}
@@ -169,11 +244,39 @@ func (gm *GraphicsManager) createPathTexture(width, height int) *ebiten.Image {
 	return img
 }
 
-// DrawTexturedBackground draws the game background with textures
+// DrawTexturedBackground draws the terrain and path. They are rendered once into an offscreen
+// image, which is drawn every frame and only re-rendered when the map size, grid size or path
+// changes. It is in world pixels, so the camera zooms it with the rest of the world.
 func (gm *GraphicsManager) DrawTexturedBackground(screen *ebiten.Image, config *GameConfig, path []Point) {
+	mapWidth, mapHeight := config.MapSize()
+	width := mapWidth * config.GridSize
+	height := mapHeight * config.GridSize
+
+	sizeChanged := gm.background == nil || gm.background.Bounds().Dx() != width || gm.background.Bounds().Dy() != height
+	if sizeChanged {
+		gm.background = ebiten.NewImage(width, height)
+	}
+	if sizeChanged || gm.backgroundGrid != config.GridSize || !slices.Equal(gm.backgroundPath, path) {
+		gm.background.Clear()
+		gm.renderBackground(gm.background, config, path)
+		gm.backgroundGrid = config.GridSize
+		gm.backgroundPath = append(gm.backgroundPath[:0], path...)
+	}
+
+	screen.DrawImage(gm.background, nil)
+}
+
+// InvalidateBackground makes the next DrawTexturedBackground render the terrain again
+func (gm *GraphicsManager) InvalidateBackground() {
+	gm.backgroundGrid = 0
+}
+
+// renderBackground draws the terrain tiles and path decorations
+func (gm *GraphicsManager) renderBackground(screen *ebiten.Image, config *GameConfig, path []Point) {
 	cellSize := float32(config.GridSize)
-	width := config.WindowWidth
-	height := config.WindowHeight
+	mapWidth, mapHeight := config.MapSize()
+	width := mapWidth * config.GridSize
+	height := mapHeight * config.GridSize
 
 	// Create a map to track path cells
 	pathCells := make(map[Point]bool)
@@ -219,10 +322,10 @@ func (gm *GraphicsManager) drawPathConnections(screen *ebiten.Image, path []Poin
 		endY := float32(next.Y)*cellSize + cellSize/2
 
 		// Draw main path line
-		vector.StrokeLine(screen, startX, startY, endX, endY, 8, color.RGBA{139, 99, 61, 255}, false)
+		vector.StrokeLine(screen, startX, startY, endX, endY, 8, gm.Theme.PathLine, false)
 
 		// Add decorative border
-		vector.StrokeLine(screen, startX, startY, endX, endY, 12, color.RGBA{101, 67, 33, 180}, false)
+		vector.StrokeLine(screen, startX, startY, endX, endY, 12, gm.Theme.PathBorder, false)
 
 		// Add consistent stones along the path
 		steps := int(math.Sqrt(float64((endX-startX)*(endX-startX)+(endY-startY)*(endY-startY))) / 15)
@@ -232,117 +335,143 @@ func (gm *GraphicsManager) drawPathConnections(screen *ebiten.Image, path []Poin
 			y := startY + t*(endY-startY)
 
 			if step%3 == 0 { // Every third step, place a stone
-				stoneColor := color.RGBA{80, 60, 40, 200}
-				vector.DrawFilledCircle(screen, x, y, 1.5, stoneColor, false)
+				vector.DrawFilledCircle(screen, x, y, 1.5, gm.Theme.PathPebble, false)
 			}
 		}
 	}
 }
 
-// DrawEnhancedTower draws a tower with improved graphics
-func (gm *GraphicsManager) DrawEnhancedTower(screen *ebiten.Image, tower *Tower, towerType int, config *GameConfig) {
+// SubmitTower queues a tower's parts on their layers: its range on the ground, its body sorted
+// with the other units, its level pips above them and its muzzle flash with the effects
+func (gm *GraphicsManager) SubmitTower(queue *RenderQueue, tower *Tower, config *GameConfig) {
 	x := float32(tower.Position.X)
 	y := float32(tower.Position.Y)
+	depth := tower.Position.Y
 
-	// Update animation
-	sprite := gm.TowerSprites[towerType]
-	if sprite != nil {
-		sprite.AnimTimer += 1.0 / 60.0
-		if sprite.AnimTimer >= sprite.AnimSpeed {
-			sprite.CurrentFrame = (sprite.CurrentFrame + 1) % sprite.FrameCount
-			sprite.AnimTimer = 0
-		}
+	// Draw range indicator with gradient effect (banks have no area of effect)
+	if config.ShowRange && tower.Range > 0 {
+		queue.Submit(LayerGroundDecals, depth, func(target *ebiten.Image) {
+			gm.drawRangeIndicator(target, x, y, float32(tower.Range))
+		})
 	}
 
-	// Draw tower base (stone foundation)
-	baseColor := color.RGBA{80, 80, 80, 255}
-	vector.DrawFilledCircle(screen, x, y, 18, baseColor, false)
-	vector.StrokeCircle(screen, x, y, 18, 2, color.RGBA{60, 60, 60, 255}, false)
+	queue.Submit(LayerUnits, depth, func(target *ebiten.Image) {
+		gm.drawTowerBody(target, x, y, tower)
+	})
 
-	switch towerType {
-	case 1: // Basic Tower
-		gm.drawBasicTower(screen, x, y, sprite)
-	case 2: // Heavy Tower
-		gm.drawHeavyTower(screen, x, y, sprite)
-	case 3: // Sniper Tower
-		gm.drawSniperTower(screen, x, y, sprite)
-	case 4: // Laser Tower
-		gm.drawLaserTower(screen, x, y, sprite)
-	case 5: // Splash Tower
-		gm.drawSplashTower(screen, x, y, sprite)
-	case 6: // Slow Tower
-		gm.drawSlowTower(screen, x, y, sprite)
+	if tower.Level > 1 {
+		queue.Submit(LayerOverlays, depth, func(target *ebiten.Image) {
+			gm.drawLevelPips(target, x, y, tower.Level)
+		})
 	}
 
-	// Draw range indicator with gradient effect
-	if config.ShowRange {
-		gm.drawRangeIndicator(screen, x, y, float32(tower.Range))
+	// Draw subtle muzzle flash effect if tower recently fired
+	if tower.LastFire < 0.05 && !config.IsSupportStructure(tower.Type) {
+		queue.Submit(LayerEffects, depth, func(target *ebiten.Image) {
+			gm.drawMuzzleFlash(target, tower)
+		})
 	}
+}
 
-	// Draw subtle muzzle flash effect if tower recently fired
-	if tower.LastFire < 0.05 {
-		gm.drawMuzzleFlash(screen, x, y, towerType)
+// drawLevelPips draws one gold pip per upgrade level below a tower
+func (gm *GraphicsManager) drawLevelPips(screen *ebiten.Image, x, y float32, level int) {
+	for pip := 1; pip < level; pip++ {
+		pipX := x - float32(level-2)*4 + float32(pip-1)*8
+		vector.DrawFilledCircle(screen, pipX, y+16, 2.5, gm.Theme.LevelPip, false)
+	}
+}
+
+// drawTowerBody draws the stone foundation and the type-specific tower on top of it, using
+// the tower's animation frame and aim
+func (gm *GraphicsManager) drawTowerBody(screen *ebiten.Image, x, y float32, tower *Tower) {
+	towerType := tower.Type
+	frame := tower.Anim.Frame
+	aim, recoil := tower.AimAngle, float32(tower.Recoil)
+
+	// Artist-provided frames replace the whole procedural tower, foundation included
+	sprite := gm.TowerSprites[towerType]
+	if image := sprite.frame(tower.Anim.current(towerAnimation)); image != nil {
+		sprite.drawFrame(screen, image, x, y, aim, &ebiten.DrawImageOptions{})
+		return
+	}
+
+	// Draw tower base (stone foundation)
+	vector.DrawFilledCircle(screen, x, y, 18, gm.Theme.Foundation, false)
+	vector.StrokeCircle(screen, x, y, 18, 2, gm.Theme.FoundationEdge, false)
+
+	switch towerType {
+	case TowerBasic:
+		gm.drawBasicTower(screen, x, y, aim, recoil)
+	case TowerHeavy:
+		gm.drawHeavyTower(screen, x, y, sprite, frame, aim, recoil)
+	case TowerSniper:
+		gm.drawSniperTower(screen, x, y, aim, recoil)
+	case TowerLaser:
+		gm.drawLaserTower(screen, x, y, sprite, frame)
+	case TowerSplash:
+		gm.drawSplashTower(screen, x, y, sprite, frame)
+	case TowerSlow:
+		gm.drawSlowTower(screen, x, y, sprite, frame)
+	case TowerBank:
+		gm.drawBankStructure(screen, x, y, sprite, frame)
+	case TowerRadar:
+		gm.drawRadarStructure(screen, x, y, sprite, frame)
+	case TowerArmory:
+		gm.drawArmoryStructure(screen, x, y, sprite, frame)
 	}
 }
 
-// drawBasicTower draws the basic tower with rotation animation
-func (gm *GraphicsManager) drawBasicTower(screen *ebiten.Image, x, y float32, sprite *Sprite) {
+// drawBasicTower draws the basic tower with its cannon at the aim angle
+func (gm *GraphicsManager) drawBasicTower(screen *ebiten.Image, x, y float32, aim float64, recoil float32) {
 	// Tower body (cylinder)
-	bodyColor := color.RGBA{128, 128, 128, 255}
-	vector.DrawFilledCircle(screen, x, y, 15, bodyColor, false)
+	vector.DrawFilledCircle(screen, x, y, 15, gm.Theme.BasicBody, false)
 
 	// Add metallic shine
-	shineColor := color.RGBA{180, 180, 180, 200}
-	vector.DrawFilledCircle(screen, x-3, y-3, 8, shineColor, false)
+	vector.DrawFilledCircle(screen, x-3, y-3, 8, gm.Theme.BasicShine, false)
 
-	// Rotating cannon based on animation frame
-	if sprite != nil {
-		angle := float64(sprite.CurrentFrame) * math.Pi / 4
-		cannonLength := float32(20)
-		cannonX := x + float32(math.Cos(angle))*cannonLength
-		cannonY := y + float32(math.Sin(angle))*cannonLength
+	// Cannon pointing at the target, pulled back by recoil after a shot
+	cannonLength := turretBarrelLength(TowerBasic) - 5*recoil
+	cannonX := x + float32(math.Cos(aim))*cannonLength
+	cannonY := y + float32(math.Sin(aim))*cannonLength
 
-		// Cannon barrel
-		vector.StrokeLine(screen, x, y, cannonX, cannonY, 4, color.RGBA{64, 64, 64, 255}, false)
+	// Cannon barrel
+	vector.StrokeLine(screen, x, y, cannonX, cannonY, 4, gm.Theme.BasicBarrel, false)
 
-		// Cannon tip
-		vector.DrawFilledCircle(screen, cannonX, cannonY, 3, color.RGBA{40, 40, 40, 255}, false)
-	}
+	// Cannon tip
+	vector.DrawFilledCircle(screen, cannonX, cannonY, 3, gm.Theme.BasicMuzzle, false)
 }
 
-// drawHeavyTower draws the heavy tower with pulsing animation
-func (gm *GraphicsManager) drawHeavyTower(screen *ebiten.Image, x, y float32, sprite *Sprite) {
+// drawHeavyTower draws the heavy tower with pulsing animation and its barrels turned to the aim
+func (gm *GraphicsManager) drawHeavyTower(screen *ebiten.Image, x, y float32, sprite *Sprite, frame int, aim float64, recoil float32) {
 	// Pulsing effect based on animation
 	pulseIntensity := float32(1.0)
 	if sprite != nil {
-		pulseIntensity = 1.0 + 0.2*float32(math.Sin(float64(sprite.CurrentFrame)*math.Pi/2))
+		pulseIntensity = 1.0 + 0.2*float32(math.Sin(float64(frame)*math.Pi/2))
 	}
 
 	// Tower body (larger, more imposing)
-	bodyColor := color.RGBA{96, 96, 96, 255}
-	vector.DrawFilledCircle(screen, x, y, 16*pulseIntensity, bodyColor, false)
+	vector.DrawFilledCircle(screen, x, y, 16*pulseIntensity, gm.Theme.HeavyBody, false)
 
 	// Add armor plating effect
-	armorColor := color.RGBA{120, 120, 120, 255}
-	vector.StrokeCircle(screen, x, y, 14*pulseIntensity, 2, armorColor, false)
-	vector.StrokeCircle(screen, x, y, 10*pulseIntensity, 1, armorColor, false)
+	vector.StrokeCircle(screen, x, y, 14*pulseIntensity, 2, gm.Theme.HeavyArmor, false)
+	vector.StrokeCircle(screen, x, y, 10*pulseIntensity, 1, gm.Theme.HeavyArmor, false)
 
-	// Multiple cannon barrels
+	// Multiple cannon barrels; the first points at the target
 	for i := 0; i < 3; i++ {
-		angle := float64(i) * 2 * math.Pi / 3
-		cannonLength := float32(18) * pulseIntensity
+		angle := aim + float64(i)*2*math.Pi/3
+		cannonLength := (turretBarrelLength(TowerHeavy) - 4*recoil) * pulseIntensity
 		cannonX := x + float32(math.Cos(angle))*cannonLength
 		cannonY := y + float32(math.Sin(angle))*cannonLength
 
 		// Thick cannon barrel
-		vector.StrokeLine(screen, x, y, cannonX, cannonY, 6, color.RGBA{48, 48, 48, 255}, false)
+		vector.StrokeLine(screen, x, y, cannonX, cannonY, 6, gm.Theme.HeavyBarrel, false)
 
 		// Cannon muzzle
-		vector.DrawFilledCircle(screen, cannonX, cannonY, 4, color.RGBA{32, 32, 32, 255}, false)
+		vector.DrawFilledCircle(screen, cannonX, cannonY, 4, gm.Theme.HeavyMuzzle, false)
 	}
 
 	// Central core with energy effect
-	coreColor := color.RGBA{255, 100, 100, uint8(100 + 100*pulseIntensity)}
+	coreColor := withAlpha(gm.Theme.HeavyCore, uint8(100+100*pulseIntensity))
 	vector.DrawFilledCircle(screen, x, y, 6*pulseIntensity, coreColor, false)
 }
 
@@ -350,69 +479,161 @@ func (gm *GraphicsManager) drawHeavyTower(screen *ebiten.Image, x, y float32, sp
 func (gm *GraphicsManager) drawRangeIndicator(screen *ebiten.Image, x, y, radius float32) {
 	// Draw multiple circles for gradient effect
 	for i := 0; i < 5; i++ {
-		alpha := uint8(20 - i*3)
-		ringColor := color.RGBA{255, 255, 255, alpha}
+		ringColor := fadeAlpha(gm.Theme.RangeRing, float64(20-i*3)/20)
 		vector.StrokeCircle(screen, x, y, radius-float32(i), 1, ringColor, false)
 	}
 }
 
-// drawMuzzleFlash creates a subtle muzzle flash effect
-func (gm *GraphicsManager) drawMuzzleFlash(screen *ebiten.Image, x, y float32, towerType int) {
+// auraColor returns the display color for an aura stat
+func (gm *GraphicsManager) auraColor(stat AuraStat) color.RGBA {
+	switch stat {
+	case AuraRange:
+		return gm.Theme.AuraRange
+	case AuraFireRate:
+		return gm.Theme.AuraFireRate
+	case AuraDamage:
+		return gm.Theme.AuraDamage
+	case AuraCritChance:
+		return gm.Theme.AuraCritChance
+	default:
+		return gm.Theme.Selection
+	}
+}
+
+// SubmitAuraRadius highlights a selected tower, its aura radii and the towers inside them. The
+// aura areas lie on the ground; the rings around towers are drawn over them.
+func (gm *GraphicsManager) SubmitAuraRadius(queue *RenderQueue, tower *Tower, towers []*Tower) {
+	x := float32(tower.Position.X)
+	y := float32(tower.Position.Y)
+
+	// Selection ring
+	queue.Submit(LayerOverlays, tower.Position.Y, func(target *ebiten.Image) {
+		vector.StrokeCircle(target, x, y, 22, 2, gm.Theme.Selection, false)
+	})
+
+	for _, aura := range tower.Auras {
+		ringColor := gm.auraColor(aura.Stat)
+		fillColor := withAlpha(ringColor, 30)
+		radius := float32(aura.Radius)
+
+		queue.Submit(LayerGroundDecals, tower.Position.Y, func(target *ebiten.Image) {
+			vector.DrawFilledCircle(target, x, y, radius, fillColor, false)
+			vector.StrokeCircle(target, x, y, radius, 2, ringColor, false)
+		})
+
+		// Mark the towers receiving this aura
+		for _, other := range towers {
+			if other == tower {
+				continue
+			}
+			dx := other.Position.X - tower.Position.X
+			dy := other.Position.Y - tower.Position.Y
+			if math.Sqrt(dx*dx+dy*dy) <= aura.Radius {
+				otherX, otherY := float32(other.Position.X), float32(other.Position.Y)
+				queue.Submit(LayerOverlays, other.Position.Y, func(target *ebiten.Image) {
+					vector.StrokeCircle(target, otherX, otherY, 20, 1, ringColor, false)
+				})
+			}
+		}
+	}
+}
+
+// SubmitPlacementGhost queues a translucent preview of a tower with its range, tinted green or
+// red by validity. The cell and range are marked on the ground; the tower is drawn over the
+// units so it is never hidden.
+func (gm *GraphicsManager) SubmitPlacementGhost(queue *RenderQueue, towerType int, position Point, rangeVal float64, cellSize int, valid bool) {
+	x := float32(position.X)
+	y := float32(position.Y)
+
+	tint := gm.Theme.PlacementValid
+	if !valid {
+		tint = gm.Theme.PlacementBlocked
+	}
+
+	queue.Submit(LayerGroundDecals, position.Y, func(target *ebiten.Image) {
+		// Highlight the target grid cell
+		half := float32(cellSize) / 2
+		vector.DrawFilledRect(target, x-half, y-half, float32(cellSize), float32(cellSize),
+			withAlpha(tint, 50), false)
+		vector.StrokeRect(target, x-half, y-half, float32(cellSize), float32(cellSize), 1, tint, false)
+
+		// Range circle (support structures without an area of effect have none)
+		if rangeVal > 0 {
+			vector.DrawFilledCircle(target, x, y, float32(rangeVal), withAlpha(tint, 25), false)
+			vector.StrokeCircle(target, x, y, float32(rangeVal), 1.5, withAlpha(tint, 180), false)
+		}
+	})
+
+	queue.Submit(LayerOverlays, position.Y, func(target *ebiten.Image) {
+		// Render the tower offscreen so it can be drawn translucent and tinted as a whole
+		if gm.ghostImage == nil {
+			gm.ghostImage = ebiten.NewImage(ghostImageSize, ghostImageSize)
+		}
+		gm.ghostImage.Clear()
+		center := float32(ghostImageSize) / 2
+		gm.drawTowerBody(gm.ghostImage, center, center, &Tower{Type: towerType, AimAngle: -math.Pi / 2})
+
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(x-center), float64(y-center))
+		op.ColorScale.Scale(float32(tint.R)/255, float32(tint.G)/255, float32(tint.B)/255, 1)
+		op.ColorScale.ScaleAlpha(0.6)
+		target.DrawImage(gm.ghostImage, op)
+	})
+}
+
+// drawMuzzleFlash creates a subtle muzzle flash effect at the end of the barrel, or at the
+// center of towers without one
+func (gm *GraphicsManager) drawMuzzleFlash(screen *ebiten.Image, tower *Tower) {
+	muzzle := tower.muzzlePosition()
+	x, y := float32(muzzle.X), float32(muzzle.Y)
+
 	// Create a simple, subtle flash effect
-	flashColor := color.RGBA{255, 255, 200, 100}
-	vector.DrawFilledCircle(screen, x, y, 8, flashColor, false)
+	vector.DrawFilledCircle(screen, x, y, 8, gm.Theme.MuzzleFlash, false)
 
 	// Add a smaller bright center
-	centerColor := color.RGBA{255, 255, 150, 150}
-	vector.DrawFilledCircle(screen, x, y, 4, centerColor, false)
+	vector.DrawFilledCircle(screen, x, y, 4, gm.Theme.MuzzleFlashCore, false)
 }
 
-// drawSniperTower draws the sniper tower with long barrel and scope
-func (gm *GraphicsManager) drawSniperTower(screen *ebiten.Image, x, y float32, sprite *Sprite) {
+// drawSniperTower draws the sniper tower with long barrel and scope at the aim angle
+func (gm *GraphicsManager) drawSniperTower(screen *ebiten.Image, x, y float32, aim float64, recoil float32) {
 	// Tower base (elevated platform)
-	baseColor := color.RGBA{70, 70, 70, 255}
-	vector.DrawFilledCircle(screen, x, y, 17, baseColor, false)
+	vector.DrawFilledCircle(screen, x, y, 17, gm.Theme.SniperBase, false)
 
 	// Elevated platform
-	platformColor := color.RGBA{90, 90, 90, 255}
-	vector.DrawFilledCircle(screen, x, y-2, 14, platformColor, false)
+	vector.DrawFilledCircle(screen, x, y-2, 14, gm.Theme.SniperPlatform, false)
 
-	// Long sniper barrel based on animation frame
-	if sprite != nil {
-		angle := float64(sprite.CurrentFrame) * math.Pi / 6 // Slower, more precise tracking
-		barrelLength := float32(35)                         // Extra long barrel
-		barrelX := x + float32(math.Cos(angle))*barrelLength
-		barrelY := y + float32(math.Sin(angle))*barrelLength
+	// Long sniper barrel pointing at the target
+	barrelLength := turretBarrelLength(TowerSniper) - 6*recoil // Extra long barrel
+	barrelX := x + float32(math.Cos(aim))*barrelLength
+	barrelY := y + float32(math.Sin(aim))*barrelLength
 
-		// Main barrel (thick and long)
-		vector.StrokeLine(screen, x, y, barrelX, barrelY, 6, color.RGBA{50, 50, 50, 255}, false)
+	// Main barrel (thick and long)
+	vector.StrokeLine(screen, x, y, barrelX, barrelY, 6, gm.Theme.SniperBarrel, false)
 
-		// Barrel tip with scope
-		vector.DrawFilledCircle(screen, barrelX, barrelY, 4, color.RGBA{30, 30, 30, 255}, false)
+	// Barrel tip with scope
+	vector.DrawFilledCircle(screen, barrelX, barrelY, 4, gm.Theme.SniperMuzzle, false)
 
-		// Scope on top of barrel
-		scopeX := x + float32(math.Cos(angle))*25
-		scopeY := y + float32(math.Sin(angle))*25
-		vector.DrawFilledRect(screen, scopeX-2, scopeY-4, 4, 8, color.RGBA{40, 40, 40, 255}, false)
+	// Scope on top of barrel
+	scopeX := x + float32(math.Cos(aim))*(25-6*recoil)
+	scopeY := y + float32(math.Sin(aim))*(25-6*recoil)
+	vector.DrawFilledRect(screen, scopeX-2, scopeY-4, 4, 8, gm.Theme.SniperScope, false)
 
-		// Scope lens glint
-		vector.DrawFilledCircle(screen, scopeX, scopeY, 1, color.RGBA{200, 200, 255, 200}, false)
-	}
+	// Scope lens glint
+	vector.DrawFilledCircle(screen, scopeX, scopeY, 1, gm.Theme.SniperLens, false)
 
 	// Central targeting system
-	vector.DrawFilledCircle(screen, x, y, 6, color.RGBA{100, 50, 50, 255}, false)
+	vector.DrawFilledCircle(screen, x, y, 6, gm.Theme.SniperCore, false)
 }
 
 // drawLaserTower draws the laser tower with energy crystals and beam emitters
-func (gm *GraphicsManager) drawLaserTower(screen *ebiten.Image, x, y float32, sprite *Sprite) {
+func (gm *GraphicsManager) drawLaserTower(screen *ebiten.Image, x, y float32, sprite *Sprite, frame int) {
 	// Tower base (crystalline structure)
-	baseColor := color.RGBA{60, 80, 120, 255}
-	vector.DrawFilledCircle(screen, x, y, 16, baseColor, false)
+	vector.DrawFilledCircle(screen, x, y, 16, gm.Theme.LaserBase, false)
 
 	// Energy crystal core
 	if sprite != nil {
-		intensity := 0.5 + 0.5*float32(math.Sin(float64(sprite.CurrentFrame)*math.Pi/8))
-		coreColor := color.RGBA{100, 150, 255, uint8(150 + 100*intensity)}
+		intensity := 0.5 + 0.5*float32(math.Sin(float64(frame)*math.Pi/8))
+		coreColor := withAlpha(gm.Theme.LaserCore, uint8(150+100*intensity))
 		vector.DrawFilledCircle(screen, x, y, 8*intensity, coreColor, false)
 	}
 
@@ -420,46 +641,42 @@ func (gm *GraphicsManager) drawLaserTower(screen *ebiten.Image, x, y float32, sp
 	for i := 0; i < 6; i++ {
 		angle := float64(i) * math.Pi / 3
 		if sprite != nil {
-			angle += float64(sprite.CurrentFrame) * math.Pi / 8 // Fast rotation
+			angle += float64(frame) * math.Pi / 8 // Fast rotation
 		}
 
 		emitterX := x + float32(math.Cos(angle))*12
 		emitterY := y + float32(math.Sin(angle))*12
 
 		// Laser emitter
-		emitterColor := color.RGBA{150, 200, 255, 255}
-		vector.DrawFilledCircle(screen, emitterX, emitterY, 3, emitterColor, false)
+		vector.DrawFilledCircle(screen, emitterX, emitterY, 3, gm.Theme.LaserEmitter, false)
 
 		// Energy beam effect
-		beamColor := color.RGBA{100, 200, 255, 100}
-		vector.StrokeLine(screen, x, y, emitterX, emitterY, 2, beamColor, false)
+		vector.StrokeLine(screen, x, y, emitterX, emitterY, 2, gm.Theme.LaserBeam, false)
 	}
 
 	// Central control unit
-	vector.DrawFilledCircle(screen, x, y, 4, color.RGBA{200, 220, 255, 255}, false)
+	vector.DrawFilledCircle(screen, x, y, 4, gm.Theme.LaserCenter, false)
 }
 
 // drawSplashTower draws the splash tower with mortar design and explosive elements
-func (gm *GraphicsManager) drawSplashTower(screen *ebiten.Image, x, y float32, sprite *Sprite) {
+func (gm *GraphicsManager) drawSplashTower(screen *ebiten.Image, x, y float32, sprite *Sprite, frame int) {
 	// Heavy base platform
-	baseColor := color.RGBA{80, 60, 40, 255}
-	vector.DrawFilledCircle(screen, x, y, 20, baseColor, false)
+	vector.DrawFilledCircle(screen, x, y, 20, gm.Theme.SplashBase, false)
 
 	// Reinforcement rings
-	vector.StrokeCircle(screen, x, y, 18, 2, color.RGBA{60, 40, 20, 255}, false)
-	vector.StrokeCircle(screen, x, y, 14, 1, color.RGBA{100, 80, 60, 255}, false)
+	vector.StrokeCircle(screen, x, y, 18, 2, gm.Theme.SplashRingOuter, false)
+	vector.StrokeCircle(screen, x, y, 14, 1, gm.Theme.SplashRingInner, false)
 
 	// Mortar tube (short and wide)
-	tubeColor := color.RGBA{40, 40, 40, 255}
-	vector.DrawFilledCircle(screen, x, y, 12, tubeColor, false)
+	vector.DrawFilledCircle(screen, x, y, 12, gm.Theme.SplashTube, false)
 
 	// Charging animation effect
 	if sprite != nil {
-		chargeLevel := float32(sprite.CurrentFrame) / float32(sprite.FrameCount)
+		chargeLevel := float32(frame) / float32(sprite.FrameCount)
 
 		// Explosive energy building up
 		if chargeLevel > 0.5 {
-			energyColor := color.RGBA{255, 150, 50, uint8(100 + 100*chargeLevel)}
+			energyColor := withAlpha(gm.Theme.SplashCharge, uint8(100+100*chargeLevel))
 			vector.DrawFilledCircle(screen, x, y-5, 6*chargeLevel, energyColor, false)
 		}
 
@@ -469,23 +686,21 @@ func (gm *GraphicsManager) drawSplashTower(screen *ebiten.Image, x, y float32, s
 			shellX := x + float32(math.Cos(angle))*16
 			shellY := y + float32(math.Sin(angle))*16
 
-			shellColor := color.RGBA{150, 100, 50, 255}
-			vector.DrawFilledCircle(screen, shellX, shellY, 2, shellColor, false)
+			vector.DrawFilledCircle(screen, shellX, shellY, 2, gm.Theme.SplashShell, false)
 		}
 	}
 
 	// Barrel opening
-	vector.DrawFilledCircle(screen, x, y, 8, color.RGBA{20, 20, 20, 255}, false)
+	vector.DrawFilledCircle(screen, x, y, 8, gm.Theme.SplashOpening, false)
 }
 
 // drawSlowTower draws the slow tower with ice crystals and freezing effects
-func (gm *GraphicsManager) drawSlowTower(screen *ebiten.Image, x, y float32, sprite *Sprite) {
+func (gm *GraphicsManager) drawSlowTower(screen *ebiten.Image, x, y float32, sprite *Sprite, frame int) {
 	// Ice crystal base
-	baseColor := color.RGBA{150, 200, 255, 200}
-	vector.DrawFilledCircle(screen, x, y, 18, baseColor, false)
+	vector.DrawFilledCircle(screen, x, y, 18, gm.Theme.SlowBase, false)
 
 	// Crystalline structure
-	crystalColor := color.RGBA{200, 230, 255, 180}
+	crystalColor := gm.Theme.SlowCrystal
 	for i := 0; i < 6; i++ {
 		angle := float64(i) * math.Pi / 3
 		crystalX := x + float32(math.Cos(angle))*14
@@ -498,90 +713,157 @@ func (gm *GraphicsManager) drawSlowTower(screen *ebiten.Image, x, y float32, spr
 
 	// Freezing wave animation
 	if sprite != nil {
-		waveRadius := 10 + float32(sprite.CurrentFrame)*2
-		waveAlpha := uint8(200 - sprite.CurrentFrame*25)
+		waveRadius := 10 + float32(frame)*2
+		waveAlpha := uint8(200 - frame*25)
 
 		if waveAlpha > 0 {
-			waveColor := color.RGBA{100, 150, 255, waveAlpha}
+			waveColor := fadeAlpha(gm.Theme.SlowWave, float64(waveAlpha)/255)
 			vector.StrokeCircle(screen, x, y, waveRadius, 2, waveColor, false)
 		}
 	}
 
 	// Central ice core
-	coreColor := color.RGBA{180, 220, 255, 255}
-	vector.DrawFilledCircle(screen, x, y, 6, coreColor, false)
+	vector.DrawFilledCircle(screen, x, y, 6, gm.Theme.SlowCore, false)
 
 	// Frost particles around tower
 	for i := 0; i < 8; i++ {
 		angle := float64(i) * math.Pi / 4
 		if sprite != nil {
-			angle += float64(sprite.CurrentFrame) * math.Pi / 16
+			angle += float64(frame) * math.Pi / 16
 		}
 
 		particleX := x + float32(math.Cos(angle))*20
 		particleY := y + float32(math.Sin(angle))*20
 
-		frostColor := color.RGBA{200, 230, 255, 150}
-		vector.DrawFilledCircle(screen, particleX, particleY, 1, frostColor, false)
+		vector.DrawFilledCircle(screen, particleX, particleY, 1, gm.Theme.SlowFrost, false)
+	}
+}
+
+// drawBankStructure draws the bank with a vault door and glinting coins
+func (gm *GraphicsManager) drawBankStructure(screen *ebiten.Image, x, y float32, sprite *Sprite, frame int) {
+	// Vault building
+	vector.DrawFilledRect(screen, x-14, y-12, 28, 24, gm.Theme.BankVault, false)
+	vector.StrokeRect(screen, x-14, y-12, 28, 24, 2, gm.Theme.BankVaultEdge, false)
+
+	// Roof columns
+	for i := 0; i < 4; i++ {
+		columnX := x - 10 + float32(i)*6.5
+		vector.DrawFilledRect(screen, columnX, y-8, 2, 14, gm.Theme.BankColumn, false)
+	}
+
+	// Gold coin with a rotating glint
+	vector.DrawFilledCircle(screen, x, y, 6, gm.Theme.BankCoin, false)
+	vector.StrokeCircle(screen, x, y, 6, 1, gm.Theme.BankCoinEdge, false)
+	if sprite != nil {
+		angle := float64(frame) * math.Pi / 4
+		glintX := x + float32(math.Cos(angle))*3
+		glintY := y + float32(math.Sin(angle))*3
+		vector.DrawFilledCircle(screen, glintX, glintY, 1.5, gm.Theme.BankGlint, false)
+	}
+}
+
+// drawRadarStructure draws the radar with a sweeping dish
+func (gm *GraphicsManager) drawRadarStructure(screen *ebiten.Image, x, y float32, sprite *Sprite, frame int) {
+	// Mounting platform
+	vector.DrawFilledCircle(screen, x, y, 14, gm.Theme.RadarPlatform, false)
+	vector.StrokeCircle(screen, x, y, 14, 1, gm.Theme.RadarPlatformEdge, false)
+
+	// Sweep beam and dish
+	if sprite != nil {
+		angle := float64(frame) * 2 * math.Pi / float64(sprite.FrameCount)
+		beamX := x + float32(math.Cos(angle))*16
+		beamY := y + float32(math.Sin(angle))*16
+		vector.StrokeLine(screen, x, y, beamX, beamY, 2, gm.Theme.RadarBeam, false)
+
+		dishX := x + float32(math.Cos(angle))*6
+		dishY := y + float32(math.Sin(angle))*6
+		vector.DrawFilledCircle(screen, dishX, dishY, 5, gm.Theme.RadarDish, false)
+	}
+
+	// Central mast
+	vector.DrawFilledCircle(screen, x, y, 3, gm.Theme.RadarMast, false)
+}
+
+// drawArmoryStructure draws the armory with crossed weapons and a forge glow
+func (gm *GraphicsManager) drawArmoryStructure(screen *ebiten.Image, x, y float32, sprite *Sprite, frame int) {
+	// Fortified building
+	vector.DrawFilledRect(screen, x-14, y-14, 28, 28, gm.Theme.ArmoryWall, false)
+	vector.StrokeRect(screen, x-14, y-14, 28, 28, 2, gm.Theme.ArmoryWallEdge, false)
+
+	// Forge glow
+	glow := float32(0.5)
+	if sprite != nil {
+		glow = 0.5 + 0.5*float32(math.Sin(float64(frame)*math.Pi/3))
 	}
+	vector.DrawFilledCircle(screen, x, y, 7, withAlpha(gm.Theme.ArmoryForge, uint8(120+100*glow)), false)
+
+	// Crossed swords
+	bladeColor := gm.Theme.ArmoryBlade
+	vector.StrokeLine(screen, x-10, y-10, x+10, y+10, 2, bladeColor, false)
+	vector.StrokeLine(screen, x+10, y-10, x-10, y+10, 2, bladeColor, false)
 }
 
-// DrawEnhancedEnemy draws an enemy with animation and effects
-func (gm *GraphicsManager) DrawEnhancedEnemy(screen *ebiten.Image, enemy *Enemy, config *GameConfig) {
+// SubmitEnemy queues an enemy: its shadow below every unit, its animated body sorted with the
+// other units by y and its health bar above them
+func (gm *GraphicsManager) SubmitEnemy(queue *RenderQueue, enemy *Enemy, config *GameConfig) {
 	if !enemy.Alive {
 		return
 	}
 
 	x := float32(enemy.Position.X)
 	y := float32(enemy.Position.Y)
+	depth := enemy.Position.Y
 
-	// Update walking animation
-	if gm.EnemySprite != nil {
-		gm.EnemySprite.AnimTimer += enemy.Speed / 60.0
-		if gm.EnemySprite.AnimTimer >= gm.EnemySprite.AnimSpeed {
-			gm.EnemySprite.CurrentFrame = (gm.EnemySprite.CurrentFrame + 1) % gm.EnemySprite.FrameCount
-			gm.EnemySprite.AnimTimer = 0
-		}
+	// Health-based color (red when damaged)
+	healthRatio := float64(enemy.Health) / float64(enemy.MaxHealth)
+
+	if image := gm.EnemySprite.frame(enemyAnimation, enemy.Anim.Frame); image != nil {
+		// Artist-provided frame, tinted the same way as the procedural body
+		queue.Submit(LayerUnits, depth, func(target *ebiten.Image) {
+			op := &ebiten.DrawImageOptions{}
+			tint := gm.enemyColor(healthRatio)
+			op.ColorScale.Scale(float32(tint.R)/255, float32(tint.G)/255, float32(tint.B)/255, 1)
+			gm.EnemySprite.drawFrame(target, image, x, y, 0, op)
+		})
+	} else {
+		queue.Submit(LayerShadows, depth, func(target *ebiten.Image) {
+			vector.DrawFilledCircle(target, x+2, y+2, 12, gm.Theme.EnemyShadow, false)
+		})
+		queue.Submit(LayerUnits, depth, func(target *ebiten.Image) {
+			gm.drawEnemyBody(target, x, y, healthRatio, enemy.Anim.Frame)
+		})
+	}
+
+	// Enhanced health bar
+	if config.ShowHealthBars {
+		queue.Submit(LayerOverlays, depth, func(target *ebiten.Image) {
+			gm.drawEnhancedHealthBar(target, x, y, enemy)
+		})
 	}
+}
 
-	// Draw shadow
-	shadowColor := color.RGBA{0, 0, 0, 100}
-	vector.DrawFilledCircle(screen, x+2, y+2, 12, shadowColor, false)
+// enemyColor blends the enemy body from its healthy to its damaged color as health drops
+func (gm *GraphicsManager) enemyColor(healthRatio float64) color.RGBA {
+	return lerpColor(gm.Theme.EnemyDamaged, gm.Theme.EnemyHealthy, healthRatio)
+}
 
+// drawEnemyBody draws the procedural enemy: breathing body, armor and eyes; its shadow is
+// submitted separately so it never covers another enemy
+func (gm *GraphicsManager) drawEnemyBody(screen *ebiten.Image, x, y float32, healthRatio float64, frame int) {
 	// Enemy body with breathing animation
-	breathEffect := 1.0 + 0.1*math.Sin(float64(gm.EnemySprite.CurrentFrame)*math.Pi/3)
+	breathEffect := 1.0 + 0.1*math.Sin(float64(frame)*math.Pi/3)
 	bodySize := float32(10) * float32(breathEffect)
 
-	// Health-based color (red when damaged)
-	healthRatio := float64(enemy.Health) / float64(enemy.MaxHealth)
-	enemyColor := color.RGBA{
-		255,
-		uint8(255 * healthRatio),
-		uint8(255 * healthRatio),
-		255,
-	}
-
 	// Draw enemy body
-	vector.DrawFilledCircle(screen, x, y, bodySize, enemyColor, false)
+	vector.DrawFilledCircle(screen, x, y, bodySize, gm.enemyColor(healthRatio), false)
 
 	// Add armor/detail effects
-	armorColor := color.RGBA{200, 200, 200, 200}
-	vector.StrokeCircle(screen, x, y, bodySize-2, 1, armorColor, false)
+	vector.StrokeCircle(screen, x, y, bodySize-2, 1, gm.Theme.EnemyArmor, false)
 
 	// Draw eyes
-	eyeColor := color.RGBA{255, 255, 0, 255}
+	eyeColor := gm.Theme.EnemyEyes
 	vector.DrawFilledCircle(screen, x-3, y-2, 2, eyeColor, false)
 	vector.DrawFilledCircle(screen, x+3, y-2, 2, eyeColor, false)
-
-	// Draw movement trail particles
-	if enemy.Speed > 0 && config.ParticleDensity > 0 {
-		gm.createMovementTrail(enemy, config)
-	}
-
-	// Enhanced health bar
-	if config.ShowHealthBars {
-		gm.drawEnhancedHealthBar(screen, x, y, enemy)
-	}
 }
 
 // drawEnhancedHealthBar draws a detailed health bar
@@ -592,200 +874,67 @@ func (gm *GraphicsManager) drawEnhancedHealthBar(screen *ebiten.Image, x, y floa
 	barY := y - 18
 
 	// Background (black border)
-	vector.DrawFilledRect(screen, barX-1, barY-1, barWidth+2, barHeight+2, color.RGBA{0, 0, 0, 200}, false)
+	vector.DrawFilledRect(screen, barX-1, barY-1, barWidth+2, barHeight+2, gm.Theme.HealthBorder, false)
 
 	// Health bar background
-	vector.DrawFilledRect(screen, barX, barY, barWidth, barHeight, color.RGBA{100, 0, 0, 255}, false)
+	vector.DrawFilledRect(screen, barX, barY, barWidth, barHeight, gm.Theme.HealthEmpty, false)
 
 	// Health bar fill with gradient
 	healthRatio := float32(enemy.Health) / float32(enemy.MaxHealth)
 	healthWidth := barWidth * healthRatio
 
-	// Color transitions: Green -> Yellow -> Red
-	var barColor color.RGBA
+	// Color steps from high through mid to low health
+	barColor := gm.Theme.HealthLow
 	if healthRatio > 0.6 {
-		barColor = color.RGBA{0, 255, 0, 255} // Green
+		barColor = gm.Theme.HealthHigh
 	} else if healthRatio > 0.3 {
-		barColor = color.RGBA{255, 255, 0, 255} // Yellow
-	} else {
-		barColor = color.RGBA{255, 0, 0, 255} // Red
+		barColor = gm.Theme.HealthMid
 	}
 
 	vector.DrawFilledRect(screen, barX, barY, healthWidth, barHeight, barColor, false)
 
 	// Add shine effect
-	shineColor := color.RGBA{255, 255, 255, 100}
-	vector.DrawFilledRect(screen, barX, barY, healthWidth, 2, shineColor, false)
+	vector.DrawFilledRect(screen, barX, barY, healthWidth, 2, gm.Theme.HealthShine, false)
 }
 
-// DrawEnhancedProjectile draws a projectile with trail effects
-func (gm *GraphicsManager) DrawEnhancedProjectile(screen *ebiten.Image, proj *Projectile, config *GameConfig) {
+// SubmitProjectile queues a projectile on the projectile layer
+func (gm *GraphicsManager) SubmitProjectile(queue *RenderQueue, proj *Projectile) {
 	if !proj.Active {
 		return
 	}
+	queue.Submit(LayerProjectiles, proj.Position.Y, func(target *ebiten.Image) {
+		gm.drawProjectile(target, proj)
+	})
+}
 
+// drawProjectile draws a projectile, its look depending on the damage it deals
+func (gm *GraphicsManager) drawProjectile(screen *ebiten.Image, proj *Projectile) {
 	x := float32(proj.Position.X)
 	y := float32(proj.Position.Y)
 
-	// Create trail effect
-	gm.createProjectileTrail(proj, config)
-
 	// Different projectile appearance based on damage (tower type indicator)
 	if proj.Damage >= 100 { // Sniper projectile
 		// Long, thin projectile
-		coreColor := color.RGBA{255, 100, 100, 255}
-		vector.DrawFilledRect(screen, x-2, y-8, 4, 16, coreColor, false)
-
-		glowColor := color.RGBA{255, 150, 150, 100}
-		vector.DrawFilledRect(screen, x-3, y-9, 6, 18, glowColor, false)
+		vector.DrawFilledRect(screen, x-2, y-8, 4, 16, gm.Theme.BulletSniper, false)
+		vector.DrawFilledRect(screen, x-3, y-9, 6, 18, gm.Theme.BulletSniperGlow, false)
 	} else if proj.Damage >= 40 { // Splash projectile
 		// Round, explosive projectile
-		coreColor := color.RGBA{255, 150, 50, 255}
-		vector.DrawFilledCircle(screen, x, y, 5, coreColor, false)
+		vector.DrawFilledCircle(screen, x, y, 5, gm.Theme.BulletSplash, false)
 
 		// Sparkling effect
-		sparkColor := color.RGBA{255, 200, 100, 200}
-		vector.DrawFilledCircle(screen, x, y, 7, sparkColor, false)
+		vector.DrawFilledCircle(screen, x, y, 7, gm.Theme.BulletSplashGlow, false)
 	} else if proj.Damage <= 15 { // Laser projectile
 		// Bright energy beam
-		coreColor := color.RGBA{100, 200, 255, 255}
-		vector.DrawFilledCircle(screen, x, y, 3, coreColor, false)
+		vector.DrawFilledCircle(screen, x, y, 3, gm.Theme.BulletLaser, false)
 
 		// Energy glow
-		glowColor := color.RGBA{150, 220, 255, 150}
-		vector.DrawFilledCircle(screen, x, y, 8, glowColor, false)
+		vector.DrawFilledCircle(screen, x, y, 8, gm.Theme.BulletLaserGlow, false)
 	} else {
 		// Standard projectile
-		coreColor := color.RGBA{255, 255, 100, 255}
-		vector.DrawFilledCircle(screen, x, y, 4, coreColor, false)
+		vector.DrawFilledCircle(screen, x, y, 4, gm.Theme.BulletBasic, false)
 
 		// Add glow effect
-		glowColor := color.RGBA{255, 200, 100, 150}
-		vector.DrawFilledCircle(screen, x, y, 6, glowColor, false)
-	}
-}
-
-// createMovementTrail creates particles behind moving enemies
-func (gm *GraphicsManager) createMovementTrail(enemy *Enemy, config *GameConfig) {
-	// Use particle density setting to control frequency
-	baseFrequency := 0.1 * config.ParticleDensity
-	if rand.Float64() < baseFrequency {
-		particle := &Particle{
-			Position: Point{enemy.Position.X, enemy.Position.Y + 5}, // No random offset
-			Velocity: Point{-enemy.Speed * 0.3, 0},                  // Reduced velocity
-			Life:     0.3,                                           // Shorter life
-			MaxLife:  0.3,
-			Color:    color.RGBA{139, 69, 19, 80}, // More transparent
-			Size:     1,                           // Smaller size
-			FadeOut:  true,
-			Active:   true,
-		}
-		gm.ParticleSystem.AddParticle(particle)
-	}
-}
-
-// createProjectileTrail creates particles behind projectiles
-func (gm *GraphicsManager) createProjectileTrail(proj *Projectile, config *GameConfig) {
-	baseFrequency := 0.3 * config.ParticleDensity
-	if rand.Float64() < baseFrequency {
-		particle := &Particle{
-			Position: Point{proj.Position.X, proj.Position.Y}, // No random offset
-			Velocity: Point{0, 0},                             // No random movement
-			Life:     0.2,                                     // Shorter life
-			MaxLife:  0.2,
-			Color:    color.RGBA{255, 200, 100, 150}, // More transparent
-			Size:     1,
-			FadeOut:  true,
-			Active:   true,
-		}
-		gm.ParticleSystem.AddParticle(particle)
-	}
-}
-
-// CreateExplosion creates explosion particles when enemies die
-func (gm *GraphicsManager) CreateExplosion(position Point, intensity int, config *GameConfig) {
-	// Create particles based on density setting
-	particleCount := int(float64(intensity*2) * config.ParticleDensity)
-	for i := 0; i < particleCount; i++ {
-		angle := float64(i) * (2 * math.Pi / float64(intensity*2)) // Even distribution
-
-		particle := &Particle{
-			Position: Point{
-				position.X,
-				position.Y,
-			},
-			Velocity: Point{
-				math.Cos(angle) * 2.0,
-				math.Sin(angle) * 2.0,
-			},
-			Life:    0.6,
-			MaxLife: 0.6,
-			Color: color.RGBA{
-				255,
-				150,
-				50,
-				200,
-			},
-			Size:    2,
-			Gravity: 0.05,
-			FadeOut: true,
-			Active:  true,
-		}
-		gm.ParticleSystem.AddParticle(particle)
-	}
-}
-
-// AddParticle adds a particle to the system
-func (ps *ParticleSystem) AddParticle(particle *Particle) {
-	ps.Particles = append(ps.Particles, particle)
-}
-
-// Update updates all particles in the system
-func (ps *ParticleSystem) Update() {
-	for i := len(ps.Particles) - 1; i >= 0; i-- {
-		particle := ps.Particles[i]
-		if !particle.Active {
-			continue
-		}
-
-		// Update particle physics
-		particle.Position.X += particle.Velocity.X
-		particle.Position.Y += particle.Velocity.Y
-		particle.Velocity.Y += particle.Gravity
-
-		// Update life
-		particle.Life -= 1.0 / 60.0
-		if particle.Life <= 0 {
-			particle.Active = false
-			ps.Particles = append(ps.Particles[:i], ps.Particles[i+1:]...)
-		}
-	}
-}
-
-// Draw renders all particles
-func (ps *ParticleSystem) Draw(screen *ebiten.Image) {
-	for _, particle := range ps.Particles {
-		if !particle.Active {
-			continue
-		}
-
-		x := float32(particle.Position.X)
-		y := float32(particle.Position.Y)
-
-		// Apply fade out effect
-		alpha := particle.Color.A
-		if particle.FadeOut {
-			alpha = uint8(float64(particle.Color.A) * (particle.Life / particle.MaxLife))
-		}
-
-		particleColor := color.RGBA{
-			particle.Color.R,
-			particle.Color.G,
-			particle.Color.B,
-			alpha,
-		}
-
-		vector.DrawFilledCircle(screen, x, y, particle.Size, particleColor, false)
+		vector.DrawFilledCircle(screen, x, y, 6, gm.Theme.BulletBasicGlow, false)
 	}
 }
 
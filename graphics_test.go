@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// renderTerrain draws the terrain of a map with a new graphics manager, which has nothing cached
+func renderTerrain(config *GameConfig, path []Point) *image.RGBA {
+	mapWidth, mapHeight := config.MapSize()
+	target := ebiten.NewImage(mapWidth*config.GridSize, mapHeight*config.GridSize)
+	NewGraphicsManager(DefaultTheme(), 0).DrawTexturedBackground(target, config, path)
+	return imageToRGBA(target)
+}
+
+func TestBackgroundCacheFollowsTerrain(t *testing.T) {
+	config := DefaultConfig()
+	config.MapWidth, config.MapHeight = 20, 15
+	gm := NewGraphicsManager(DefaultTheme(), 0)
+	g := (&Scene{}).game(gm, config)
+	g.camera = NewCamera(g.config)
+	g.worldImage = ebiten.NewImage(20*g.config.GridSize, 15*g.config.GridSize)
+	screen := ebiten.NewImage(g.config.WindowWidth, g.config.WindowHeight)
+
+	steps := []struct {
+		name   string
+		change func()
+	}{
+		{"first frame", func() {}},
+		{"zoomed in", func() { g.camera.ZoomAt(2, 100, 100) }},
+		{"zoomed out", func() { g.camera.ZoomAt(0.5, 100, 100) }},
+		{"new path", func() { g.path = []Point{{0, 3}, {10, 3}, {10, 12}, {19, 12}} }},
+		{"path moved in place", func() { g.path[1], g.path[2] = Point{12, 3}, Point{12, 12} }},
+		{"smaller grid", func() { g.config.GridSize = 30 }},
+		{"larger map", func() { g.config.MapWidth, g.config.MapHeight = 25, 18 }},
+		{"zoomed in on the new map", func() { g.camera.ZoomAt(1.5, 300, 200) }},
+	}
+	for _, step := range steps {
+		step.change()
+		g.drawGameContent(screen)
+		if n := diffPixels(imageToRGBA(gm.background), renderTerrain(g.config, g.path), 0); n > 0 {
+			t.Errorf("%s: %d pixels of the cached terrain differ from a fresh render", step.name, n)
+		}
+	}
+}
+
+// BenchmarkBackground draws the terrain of a map, either from the cached image or re-rendering
+// it every frame, which costs what drawing the terrain did before the cache existed plus one
+// image copy
+func BenchmarkBackground(b *testing.B) {
+	for _, size := range [][2]int{{20, 15}, {100, 75}} {
+		for _, cached := range []bool{false, true} {
+			mapWidth, mapHeight, cached := size[0], size[1], cached
+			name := fmt.Sprintf("rebuilt/%dx%d", mapWidth, mapHeight)
+			if cached {
+				name = fmt.Sprintf("cached/%dx%d", mapWidth, mapHeight)
+			}
+			b.Run(name, func(b *testing.B) {
+				config := DefaultConfig()
+				config.MapWidth, config.MapHeight = mapWidth, mapHeight
+				path := defaultPath(mapWidth, mapHeight)
+				gm := NewGraphicsManager(DefaultTheme(), 0)
+				target := ebiten.NewImage(mapWidth*config.GridSize, mapHeight*config.GridSize)
+				gm.DrawTexturedBackground(target, config, path)
+				flushDrawing(target)
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for n := 0; n < b.N; n++ {
+					if !cached {
+						gm.InvalidateBackground()
+					}
+					target.Clear()
+					gm.DrawTexturedBackground(target, config, path)
+					flushDrawing(target)
+				}
+			})
+		}
+	}
+}
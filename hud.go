@@ -0,0 +1,341 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tower bar layout along the bottom edge of the screen
+const (
+	towerBarButtonWidth  = 82
+	towerBarButtonHeight = 40
+	towerBarSpacing      = 4
+	towerBarPadding      = 4
+	towerTypeCount       = TowerArmory
+)
+
+// gameScreens holds the retained widget trees for every screen
+type gameScreens struct {
+	menu        *Panel
+	menuFooter  *Panel
+	hud         *Panel
+	modeInfo    *Panel
+	towerBar    *Panel
+	towerPanel  *Panel
+	pauseBanner *Panel
+	levelInfo   *Panel
+	gameOver    *Panel
+	victory     *Panel
+	controls    *Panel
+}
+
+// newGameScreens builds all screens; widgets read live game state through closures
+func newGameScreens(g *Game) *gameScreens {
+	return &gameScreens{
+		menu:        newMenuPanel(g),
+		menuFooter:  newMenuFooterPanel(g),
+		hud:         newHUDPanel(g),
+		modeInfo:    newModeInfoPanel(g),
+		towerBar:    newTowerBar(g),
+		towerPanel:  newTowerPanel(g),
+		pauseBanner: newPauseBanner(g),
+		levelInfo:   newLevelInfoPanel(g),
+		controls:    newControlsPanel(g),
+		gameOver: newEndScreen(g, "gameover.title", func() string { return g.modeManager.gameOverText(g) },
+			"button.restart", func() { g.modeManager.restartCurrentMode(g) }),
+		victory: newEndScreen(g, "victory.title", func() string { return g.loc.T("victory.text") },
+			"button.play_again", func() { g.modeManager.startNormalMode(g) }),
+	}
+}
+
+// roots returns the root panels shown in a game state, bottom to top
+func (s *gameScreens) roots(state GameState) []*Panel {
+	switch state {
+	case StateMenu:
+		return []*Panel{s.menu, s.menuFooter}
+	case StatePlaying, StatePaused:
+		return []*Panel{s.levelInfo, s.hud, s.modeInfo, s.towerBar, s.pauseBanner, s.towerPanel}
+	case StateGameOver:
+		return []*Panel{s.gameOver}
+	case StateVictory:
+		return []*Panel{s.victory}
+	case StateControls:
+		return []*Panel{s.controls}
+	}
+	return nil
+}
+
+// centerOn returns a PositionFunc that centers a panel horizontally with its top at y
+func centerOn(g *Game, y int) func(w, h int) (int, int) {
+	return anchor(g, 0.5, 0, 0, y)
+}
+
+// centerOnScreen returns a PositionFunc that centers a panel on the screen
+func centerOnScreen(g *Game) func(w, h int) (int, int) {
+	return anchor(g, 0.5, 0.5, 0, 0)
+}
+
+// newMenuPanel builds the main menu: title, mode list and description of the selection
+func newMenuPanel(g *Game) *Panel {
+	gmm := g.modeManager
+	return &Panel{
+		PositionFunc: centerOn(g, 70),
+		Spacing:      12,
+		MinWidth:     400,
+		Children: []Widget{
+			&Label{Text: g.loc.T("menu.title"), Style: TextTitle, Align: AlignCenter, Color: g.theme.Title},
+			&Label{Text: g.loc.T("menu.subtitle"), Style: TextHeading, Align: AlignCenter},
+			&List{
+				Items: func() []string {
+					labels := make([]string, len(gmm.MenuOptions))
+					for i, option := range gmm.MenuOptions {
+						labels[i] = g.loc.T(option)
+					}
+					return labels
+				},
+				SelectedFunc: func() int { return gmm.MenuSelection },
+				OnSelect:     func(index int) { gmm.MenuSelection = index },
+				OnActivate: func(index int) {
+					gmm.MenuSelection = index
+					gmm.MenuClicked = true
+				},
+				Style:      TextHeading,
+				ItemHeight: 50,
+				Width:      220,
+			},
+			&Label{TextFunc: func() string { return gmm.menuDescription(g) }},
+		},
+	}
+}
+
+// newMenuFooterPanel shows the current selection and the menu controls
+func newMenuFooterPanel(g *Game) *Panel {
+	gmm := g.modeManager
+	return &Panel{
+		PositionFunc: anchor(g, 0, 1, 50, 40),
+		Spacing:      14,
+		Children: []Widget{
+			&Label{TextFunc: func() string {
+				return g.loc.T("menu.selected", g.loc.T(gmm.MenuOptions[gmm.MenuSelection]), g.input.Label(ActionConfirm))
+			}},
+			&Label{TextFunc: func() string {
+				return g.loc.T("menu.controls", g.input.Label(ActionMenuUp), g.input.Label(ActionMenuDown), g.input.Label(ActionConfirm))
+			}},
+		},
+	}
+}
+
+// newHUDPanel shows money, lives, wave status and economy info in the top-left corner
+func newHUDPanel(g *Game) *Panel {
+	return &Panel{
+		PositionFunc: anchor(g, 0, 0, 0, 0),
+		Padding:      4,
+		Background:   g.theme.HUDBackground,
+		PassThrough:  true,
+		Children:     []Widget{&Label{TextFunc: g.hudText, Style: TextHUD}},
+	}
+}
+
+// hudText builds the status lines of the HUD
+func (g *Game) hudText() string {
+	loc := g.loc
+
+	// Add wave progress feedback
+	waveStatus := ""
+	if g.enemiesSpawned < g.enemiesPerWave {
+		waveStatus = loc.T("hud.wave.spawning", g.enemiesSpawned, g.enemiesPerWave)
+	} else if len(g.enemies) > 0 {
+		waveStatus = loc.T("hud.wave.remaining", len(g.enemies))
+	} else if len(g.enemies) == 0 && g.enemiesSpawned >= g.enemiesPerWave {
+		waveStatus = loc.T("hud.wave.next", g.input.Label(ActionNextWave))
+	}
+
+	moneyText := loc.Money(g.money)
+	livesText := loc.Number(g.lives)
+	if g.hasInfiniteResources() {
+		moneyText = loc.T("hud.unlimited")
+		livesText = loc.T("hud.unlimited")
+		waveStatus = loc.T("hud.wave.enemies", len(g.enemies))
+	}
+
+	lines := []string{
+		loc.T("hud.status", moneyText, livesText, g.wave, speedLabel(g.gameSpeed), waveStatus),
+		loc.T("hud.selected", g.towerName(g.selectedTowerType)),
+	}
+
+	// Add bonus display if recently earned
+	if g.bonusDisplayTimer > 0 {
+		lines = append(lines, loc.T("hud.early_bonus", loc.Money(g.lastBonusEarned)))
+	}
+
+	// Interest preview so players can decide whether to bank money
+	if g.paysInterest() {
+		lines = append(lines, loc.T("hud.interest", g.config.InterestRate, loc.Money(g.config.InterestCap),
+			loc.Money(g.config.CalculateInterest(g.money)), loc.Money(g.lastInterestEarned)))
+	}
+
+	if income := g.bankIncome(); income > 0 && !g.hasInfiniteResources() {
+		lines = append(lines, loc.T("hud.banks", loc.Money(income)))
+	}
+
+	if g.config.ShowFPS {
+		lines = append(lines, loc.T("hud.fps", ebiten.ActualFPS()))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// newModeInfoPanel shows mode progress and controls just above the tower bar
+func newModeInfoPanel(g *Game) *Panel {
+	return &Panel{
+		PositionFunc: func(w, h int) (int, int) {
+			_, barH := g.screens.towerBar.Measure(g.ui)
+			return g.ui.Px(10), g.screenHeight - barH - g.ui.Px(8) - h
+		},
+		Spacing:     4,
+		PassThrough: true,
+		Children: []Widget{
+			&Label{TextFunc: func() string { return g.modeManager.modeInfoText(g) }, Style: TextHUD},
+			&Label{TextFunc: func() string {
+				return g.loc.T("hud.controls", g.input.Label(ActionPause), g.input.Label(ActionSpeedDown), g.input.Label(ActionSpeedUp))
+			}, Style: TextHUD},
+		},
+	}
+}
+
+// newTowerBar builds one button per tower type along the bottom of the screen
+func newTowerBar(g *Game) *Panel {
+	bar := &Panel{
+		Direction:    LayoutHorizontal,
+		PositionFunc: anchor(g, 0.5, 1, 0, 0),
+		Padding:      towerBarPadding,
+		Spacing:      towerBarSpacing,
+		Background:   g.theme.Panel,
+	}
+
+	for towerType := 1; towerType <= towerTypeCount; towerType++ {
+		towerType := towerType
+		bar.Children = append(bar.Children, &Button{
+			LabelFunc: func() string {
+				cost, _, _, _ := g.config.GetTowerStats(towerType)
+				return fmt.Sprintf("%s %s\n%s", g.input.Label(towerSelectAction(towerType)), g.towerName(towerType), g.loc.Money(cost))
+			},
+			W:            towerBarButtonWidth,
+			H:            towerBarButtonHeight,
+			OnClick:      func() { g.selectedTowerType = towerType },
+			SelectedFunc: func() bool { return g.selectedTowerType == towerType },
+			TooltipFunc:  func() string { return g.towerTypeTooltip(towerType) },
+		})
+	}
+	return bar
+}
+
+// towerTypeTooltip describes a tower type before it is built
+func (g *Game) towerTypeTooltip(towerType int) string {
+	loc := g.loc
+	cost, damage, rangeVal, fireRate := g.config.GetTowerStats(towerType)
+	name := g.towerName(towerType)
+
+	var lines []string
+	if g.config.IsSupportStructure(towerType) {
+		lines = append(lines, loc.T("tooltip.structure", name, loc.Money(cost)))
+	} else {
+		lines = append(lines,
+			loc.T("tooltip.tower", name, loc.Money(cost)),
+			loc.T("tooltip.stats", damage, rangeVal, fireRate))
+	}
+
+	switch towerType {
+	case TowerSplash:
+		lines = append(lines, loc.T("tooltip.splash", g.config.SplashRadius))
+	case TowerSlow:
+		lines = append(lines, loc.T("tooltip.slow", (1-g.config.SlowEffect)*100))
+	case TowerBank:
+		lines = append(lines, loc.T("tooltip.income", loc.Money(g.config.BankIncome)))
+	}
+	for _, aura := range g.config.GetTowerAuras(towerType) {
+		lines = append(lines, loc.T("tooltip.aura", g.describeAura(aura)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// towerName returns the localized name of a tower type
+func (g *Game) towerName(towerType int) string {
+	if towerType < TowerBasic || towerType > towerTypeCount {
+		return g.loc.T("tower.unknown")
+	}
+	return g.loc.T(fmt.Sprintf("tower.%d", towerType))
+}
+
+// newPauseBanner shows the tactical pause status in the top-right corner
+func newPauseBanner(g *Game) *Panel {
+	return &Panel{
+		PositionFunc: anchor(g, 1, 0, 8, 8),
+		Padding:      6,
+		Background:   g.theme.BannerBackground,
+		VisibleFunc:  func() bool { return g.modeManager.CurrentState == StatePaused },
+		Children: []Widget{
+			&Label{Text: g.loc.T("pause.title"), Style: TextHeading},
+			&Label{TextFunc: func() string {
+				return g.loc.T("pause.controls", g.input.Label(ActionPause), g.input.Label(ActionMenu))
+			}, Style: TextHUD},
+			&Label{TextFunc: func() string {
+				return g.loc.T("pause.step", g.input.Label(ActionFrameStep), g.input.Label(ActionSpeedDown),
+					g.input.Label(ActionSpeedUp), speedLabel(g.gameSpeed))
+			}, Style: TextHUD},
+		},
+	}
+}
+
+// newLevelInfoPanel shows the level introduction and last wave's payout
+func newLevelInfoPanel(g *Game) *Panel {
+	gmm := g.modeManager
+	return &Panel{
+		PositionFunc: centerOnScreen(g),
+		Spacing:      16,
+		PassThrough:  true,
+		VisibleFunc:  func() bool { return gmm.ShowLevelInfo && gmm.CurrentState == StatePlaying },
+		Children: []Widget{
+			&Label{TextFunc: func() string { return gmm.levelInfoTitle(g) }, Style: TextTitle, Align: AlignCenter, Color: g.theme.Title},
+			&Label{TextFunc: func() string { return gmm.levelInfoText(g) }, Align: AlignCenter},
+			&Label{TextFunc: func() string { return gmm.levelInfoCountdown(g) }, Align: AlignCenter},
+			&Label{TextFunc: func() string { return gmm.waveSummaryText(g) }, Align: AlignCenter},
+		},
+	}
+}
+
+// newEndScreen builds the game over and victory screens from localization keys
+func newEndScreen(g *Game, titleKey string, textFunc func() string, restartKey string, restart func()) *Panel {
+	return &Panel{
+		PositionFunc: centerOnScreen(g),
+		Padding:      16,
+		Spacing:      12,
+		MinWidth:     320,
+		Background:   g.theme.Panel,
+		Border:       g.theme.PanelBorder,
+		Children: []Widget{
+			&Label{Text: g.loc.T(titleKey), Style: TextTitle, Align: AlignCenter, Color: g.theme.Title},
+			&Label{TextFunc: textFunc, Align: AlignCenter},
+			&Panel{
+				Direction: LayoutHorizontal,
+				Align:     AlignCenter,
+				Spacing:   12,
+				Children: []Widget{
+					&Button{
+						LabelFunc: func() string { return g.loc.T("button.menu", g.input.Label(ActionConfirm)) },
+						W:         140,
+						OnClick:   func() { g.modeManager.returnToMenu(g) },
+					},
+					&Button{
+						LabelFunc: func() string { return g.loc.T(restartKey, g.input.Label(ActionRestart)) },
+						W:         140,
+						OnClick:   restart,
+					},
+				},
+			},
+		},
+	}
+}
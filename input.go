@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Action is a player command that can be bound to one or more keys
+type Action int
+
+const (
+	ActionPause Action = iota
+	ActionRestart
+	ActionMenu
+	ActionMenuUp
+	ActionMenuDown
+	ActionConfirm
+	ActionNextWave
+	ActionSpeedUp
+	ActionSpeedDown
+	ActionFrameStep
+	ActionUpgrade
+	ActionSell
+	ActionTargeting
+	ActionSelectTower1
+	ActionSelectTower2
+	ActionSelectTower3
+	ActionSelectTower4
+	ActionSelectTower5
+	ActionSelectTower6
+	ActionSelectTower7
+	ActionSelectTower8
+	ActionSelectTower9
+	ActionSandboxSpawn
+	ActionSandboxHealthUp
+	ActionSandboxHealthDown
+	ActionSandboxSpeedUp
+	ActionSandboxSpeedDown
+	ActionSandboxClear
+	ActionSandboxLargeStep
+	ActionCameraUp
+	ActionCameraDown
+	ActionCameraLeft
+	ActionCameraRight
+	ActionCameraReset
+	ActionScreenshot
+	ActionPlace
+	ActionPrevTowerType
+	ActionNextTowerType
+	ActionCursorUp
+	ActionCursorDown
+	ActionCursorLeft
+	ActionCursorRight
+)
+
+// actionBinding ties an action to the config fields holding its key and gamepad button names
+type actionBinding struct {
+	Action  Action
+	Name    string                      // Config name without the "_key" suffix; also the "action.<name>" localization key
+	Field   func(c *GameConfig) *string // Keyboard keys; nil for gamepad-only actions
+	Gamepad func(c *GameConfig) *string // Gamepad buttons; nil for keyboard-only actions
+}
+
+// actionBindings lists every rebindable action in the order shown on the controls screen
+var actionBindings = []actionBinding{
+	{ActionPause, "pause", func(c *GameConfig) *string { return &c.PauseKey }, func(c *GameConfig) *string { return &c.GamepadPauseButton }},
+	{ActionRestart, "restart", func(c *GameConfig) *string { return &c.RestartKey }, func(c *GameConfig) *string { return &c.GamepadRestartButton }},
+	{ActionMenu, "menu", func(c *GameConfig) *string { return &c.MenuKey }, func(c *GameConfig) *string { return &c.GamepadMenuButton }},
+	{ActionMenuUp, "menu_up", func(c *GameConfig) *string { return &c.MenuUpKey }, func(c *GameConfig) *string { return &c.GamepadMenuUpButton }},
+	{ActionMenuDown, "menu_down", func(c *GameConfig) *string { return &c.MenuDownKey }, func(c *GameConfig) *string { return &c.GamepadMenuDownButton }},
+	{ActionConfirm, "confirm", func(c *GameConfig) *string { return &c.ConfirmKey }, func(c *GameConfig) *string { return &c.GamepadConfirmButton }},
+	{ActionNextWave, "next_wave", func(c *GameConfig) *string { return &c.NextWaveKey }, func(c *GameConfig) *string { return &c.GamepadNextWaveButton }},
+	{ActionSpeedUp, "speed_up", func(c *GameConfig) *string { return &c.SpeedUpKey }, nil},
+	{ActionSpeedDown, "speed_down", func(c *GameConfig) *string { return &c.SpeedDownKey }, nil},
+	{ActionFrameStep, "frame_step", func(c *GameConfig) *string { return &c.FrameStepKey }, nil},
+	{ActionUpgrade, "upgrade", func(c *GameConfig) *string { return &c.UpgradeKey }, func(c *GameConfig) *string { return &c.GamepadUpgradeButton }},
+	{ActionSell, "sell", func(c *GameConfig) *string { return &c.SellKey }, func(c *GameConfig) *string { return &c.GamepadSellButton }},
+	{ActionTargeting, "targeting", func(c *GameConfig) *string { return &c.TargetingKey }, func(c *GameConfig) *string { return &c.GamepadTargetingButton }},
+	{ActionSelectTower1, "tower_select_1", func(c *GameConfig) *string { return &c.TowerSelect1Key }, nil},
+	{ActionSelectTower2, "tower_select_2", func(c *GameConfig) *string { return &c.TowerSelect2Key }, nil},
+	{ActionSelectTower3, "tower_select_3", func(c *GameConfig) *string { return &c.TowerSelect3Key }, nil},
+	{ActionSelectTower4, "tower_select_4", func(c *GameConfig) *string { return &c.TowerSelect4Key }, nil},
+	{ActionSelectTower5, "tower_select_5", func(c *GameConfig) *string { return &c.TowerSelect5Key }, nil},
+	{ActionSelectTower6, "tower_select_6", func(c *GameConfig) *string { return &c.TowerSelect6Key }, nil},
+	{ActionSelectTower7, "tower_select_7", func(c *GameConfig) *string { return &c.TowerSelect7Key }, nil},
+	{ActionSelectTower8, "tower_select_8", func(c *GameConfig) *string { return &c.TowerSelect8Key }, nil},
+	{ActionSelectTower9, "tower_select_9", func(c *GameConfig) *string { return &c.TowerSelect9Key }, nil},
+	{ActionSandboxSpawn, "sandbox_spawn", func(c *GameConfig) *string { return &c.SandboxSpawnKey }, nil},
+	{ActionSandboxHealthUp, "sandbox_health_up", func(c *GameConfig) *string { return &c.SandboxHealthUpKey }, nil},
+	{ActionSandboxHealthDown, "sandbox_health_down", func(c *GameConfig) *string { return &c.SandboxHealthDownKey }, nil},
+	{ActionSandboxSpeedUp, "sandbox_speed_up", func(c *GameConfig) *string { return &c.SandboxSpeedUpKey }, nil},
+	{ActionSandboxSpeedDown, "sandbox_speed_down", func(c *GameConfig) *string { return &c.SandboxSpeedDownKey }, nil},
+	{ActionSandboxClear, "sandbox_clear", func(c *GameConfig) *string { return &c.SandboxClearKey }, nil},
+	{ActionSandboxLargeStep, "sandbox_large_step", func(c *GameConfig) *string { return &c.SandboxLargeStepKey }, nil},
+	{ActionCameraUp, "camera_up", func(c *GameConfig) *string { return &c.CameraUpKey }, nil},
+	{ActionCameraDown, "camera_down", func(c *GameConfig) *string { return &c.CameraDownKey }, nil},
+	{ActionCameraLeft, "camera_left", func(c *GameConfig) *string { return &c.CameraLeftKey }, nil},
+	{ActionCameraRight, "camera_right", func(c *GameConfig) *string { return &c.CameraRightKey }, nil},
+	{ActionCameraReset, "camera_reset", func(c *GameConfig) *string { return &c.CameraResetKey }, nil},
+	{ActionScreenshot, "screenshot", func(c *GameConfig) *string { return &c.ScreenshotKey }, nil},
+
+	{ActionPlace, "place", nil, func(c *GameConfig) *string { return &c.GamepadPlaceButton }},
+	{ActionPrevTowerType, "tower_prev", nil, func(c *GameConfig) *string { return &c.GamepadPrevTowerButton }},
+	{ActionNextTowerType, "tower_next", nil, func(c *GameConfig) *string { return &c.GamepadNextTowerButton }},
+	{ActionCursorUp, "cursor_up", nil, func(c *GameConfig) *string { return &c.GamepadCursorUpButton }},
+	{ActionCursorDown, "cursor_down", nil, func(c *GameConfig) *string { return &c.GamepadCursorDownButton }},
+	{ActionCursorLeft, "cursor_left", nil, func(c *GameConfig) *string { return &c.GamepadCursorLeftButton }},
+	{ActionCursorRight, "cursor_right", nil, func(c *GameConfig) *string { return &c.GamepadCursorRightButton }},
+}
+
+// inputContext groups actions whose keys are checked against each other when rebinding.
+// Actions of different contexts may share a key, such as Space confirming in menus and
+// starting the next wave.
+type inputContext int
+
+const (
+	contextGlobal   inputContext = iota // Active everywhere, so its keys conflict with every context
+	contextMenu                         // Menu navigation
+	contextGameplay                     // Commands while playing
+	contextCamera                       // Camera panning while playing
+	contextSandbox                      // Sandbox commands
+)
+
+// context returns the context an action is active in
+func (a Action) context() inputContext {
+	switch {
+	case a == ActionPause || a == ActionScreenshot:
+		return contextGlobal
+	case a == ActionMenuUp || a == ActionMenuDown || a == ActionConfirm:
+		return contextMenu
+	case a >= ActionCameraUp && a <= ActionCameraReset:
+		return contextCamera
+	case a >= ActionSandboxSpawn && a <= ActionSandboxLargeStep:
+		return contextSandbox
+	default:
+		return contextGameplay
+	}
+}
+
+// conflictsWith reports whether two actions are in the same context, or one is global, so they
+// must not share a key
+func (a Action) conflictsWith(other Action) bool {
+	ca, co := a.context(), other.context()
+	return ca == co || ca == contextGlobal || co == contextGlobal
+}
+
+// towerSelectAction returns the action that selects a tower type (1-9)
+func towerSelectAction(towerType int) Action {
+	return ActionSelectTower1 + Action(towerType-1)
+}
+
+// InputMap resolves actions to the keys and gamepad buttons bound to them; the per-frame
+// state of those inputs is tracked by Input
+type InputMap struct {
+	keys     map[Action][]ebiten.Key
+	buttons  map[Action][]ebiten.StandardGamepadButton
+	gamepad  bool    // Gamepad support enabled in the config
+	deadzone float64 // Stick deflection below this is ignored
+}
+
+// NewInputMap parses the key and gamepad bindings of a config; actions with an invalid
+// binding keep their defaults and are reported in the returned error
+func NewInputMap(config *GameConfig) (*InputMap, error) {
+	defaults := DefaultConfig()
+	m := &InputMap{
+		keys:     make(map[Action][]ebiten.Key),
+		buttons:  make(map[Action][]ebiten.StandardGamepadButton),
+		gamepad:  config.GamepadEnabled,
+		deadzone: config.GamepadDeadzone,
+	}
+
+	var problems []string
+	for _, binding := range actionBindings {
+		if binding.Field != nil {
+			keys, err := parseKeyList(*binding.Field(config))
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s_key: %v", binding.Name, err))
+				keys, _ = parseKeyList(*binding.Field(defaults))
+			}
+			m.keys[binding.Action] = keys
+		}
+		if binding.Gamepad != nil && config.GamepadEnabled {
+			buttons, err := parseButtonList(*binding.Gamepad(config))
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("gamepad %s: %v", binding.Name, err))
+				buttons, _ = parseButtonList(*binding.Gamepad(defaults))
+			}
+			m.buttons[binding.Action] = buttons
+		}
+	}
+
+	if len(problems) > 0 {
+		return m, fmt.Errorf("invalid key bindings: %s", strings.Join(problems, "; "))
+	}
+	return m, nil
+}
+
+// parseKeyList parses comma-separated key names such as "Escape,P"
+func parseKeyList(text string) ([]ebiten.Key, error) {
+	var keys []ebiten.Key
+	for _, name := range strings.Split(text, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		var key ebiten.Key
+		if err := key.UnmarshalText([]byte(name)); err != nil {
+			return nil, fmt.Errorf("unknown key %q", name)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no key bound")
+	}
+	return keys, nil
+}
+
+// gamepadButtonNames maps config names to buttons of Ebiten's standard gamepad layout
+// (named after an Xbox controller)
+var gamepadButtonNames = map[string]ebiten.StandardGamepadButton{
+	"A":          ebiten.StandardGamepadButtonRightBottom,
+	"B":          ebiten.StandardGamepadButtonRightRight,
+	"X":          ebiten.StandardGamepadButtonRightLeft,
+	"Y":          ebiten.StandardGamepadButtonRightTop,
+	"LB":         ebiten.StandardGamepadButtonFrontTopLeft,
+	"RB":         ebiten.StandardGamepadButtonFrontTopRight,
+	"LT":         ebiten.StandardGamepadButtonFrontBottomLeft,
+	"RT":         ebiten.StandardGamepadButtonFrontBottomRight,
+	"Back":       ebiten.StandardGamepadButtonCenterLeft,
+	"Start":      ebiten.StandardGamepadButtonCenterRight,
+	"Home":       ebiten.StandardGamepadButtonCenterCenter,
+	"LeftStick":  ebiten.StandardGamepadButtonLeftStick,
+	"RightStick": ebiten.StandardGamepadButtonRightStick,
+	"DpadUp":     ebiten.StandardGamepadButtonLeftTop,
+	"DpadDown":   ebiten.StandardGamepadButtonLeftBottom,
+	"DpadLeft":   ebiten.StandardGamepadButtonLeftLeft,
+	"DpadRight":  ebiten.StandardGamepadButtonLeftRight,
+}
+
+// parseButtonList parses comma-separated gamepad button names such as "A,Start"; unlike
+// keys, an action may be left without a button
+func parseButtonList(text string) ([]ebiten.StandardGamepadButton, error) {
+	var buttons []ebiten.StandardGamepadButton
+	for _, name := range strings.Split(text, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		button, ok := gamepadButtonNames[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown gamepad button %q", name)
+		}
+		buttons = append(buttons, button)
+	}
+	return buttons, nil
+}
+
+// stickDirection maps the actions that also follow a stick to the axis and sign that trigger them;
+// the left stick moves the menu and grid cursors, the right stick pans the camera
+var stickDirection = map[Action]struct {
+	Axis ebiten.StandardGamepadAxis
+	Sign float64
+}{
+	ActionMenuUp:      {ebiten.StandardGamepadAxisLeftStickVertical, -1},
+	ActionMenuDown:    {ebiten.StandardGamepadAxisLeftStickVertical, 1},
+	ActionCursorUp:    {ebiten.StandardGamepadAxisLeftStickVertical, -1},
+	ActionCursorDown:  {ebiten.StandardGamepadAxisLeftStickVertical, 1},
+	ActionCursorLeft:  {ebiten.StandardGamepadAxisLeftStickHorizontal, -1},
+	ActionCursorRight: {ebiten.StandardGamepadAxisLeftStickHorizontal, 1},
+	ActionCameraUp:    {ebiten.StandardGamepadAxisRightStickVertical, -1},
+	ActionCameraDown:  {ebiten.StandardGamepadAxisRightStickVertical, 1},
+	ActionCameraLeft:  {ebiten.StandardGamepadAxisRightStickHorizontal, -1},
+	ActionCameraRight: {ebiten.StandardGamepadAxisRightStickHorizontal, 1},
+}
+
+// keyName returns the config name of a key ("1" rather than ebiten's "Digit1")
+func keyName(key ebiten.Key) string {
+	return strings.TrimPrefix(key.String(), "Digit")
+}
+
+// Keys returns the keys bound to an action
+func (m *InputMap) Keys(action Action) []ebiten.Key {
+	return m.keys[action]
+}
+
+// Label lists the keys bound to an action for on-screen hints, e.g. "Escape/P"
+func (m *InputMap) Label(action Action) string {
+	names := make([]string, len(m.keys[action]))
+	for i, key := range m.keys[action] {
+		names[i] = keyName(key)
+	}
+	return strings.Join(names, "/")
+}
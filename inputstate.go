@@ -0,0 +1,258 @@
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// inputDevice is the kind of physical input an inputSource refers to
+type inputDevice int
+
+const (
+	deviceKeyboard inputDevice = iota
+	deviceMouse
+	deviceGamepadButton
+	deviceGamepadStick
+)
+
+// inputSource identifies one physical input: a key, a mouse button, a gamepad button or a
+// stick direction (Code is axis*2, plus 1 for the positive direction)
+type inputSource struct {
+	Device  inputDevice
+	Gamepad ebiten.GamepadID
+	Code    int
+}
+
+// sourceState is the held state of an input this frame and last frame
+type sourceState struct {
+	held     bool
+	wasHeld  bool
+	consumed bool // A handler already acted on this frame's press or release
+}
+
+func (s *sourceState) justPressed() bool {
+	return s.held && !s.wasHeld && !s.consumed
+}
+
+func (s *sourceState) justReleased() bool {
+	return !s.held && s.wasHeld && !s.consumed
+}
+
+// Input tracks every key, mouse button and gamepad input once per frame so handlers see
+// presses and releases as events. A handler that acts on an event consumes it, so one press
+// does exactly one thing even when the game state changes within the frame.
+type Input struct {
+	bindings *InputMap
+	states   map[inputSource]*sourceState
+	gamepads []ebiten.GamepadID // Connected gamepads with a standard layout
+	keys     []ebiten.Key       // Scratch buffer for polling the keyboard
+}
+
+// NewInput creates an input tracker with the given bindings
+func NewInput(bindings *InputMap) *Input {
+	return &Input{
+		bindings: bindings,
+		states:   make(map[inputSource]*sourceState),
+	}
+}
+
+// SetBindings swaps the bindings; the held state of every input carries over so a key that
+// is already down does not count as a new press
+func (in *Input) SetBindings(bindings *InputMap) {
+	in.bindings = bindings
+}
+
+// state returns the tracked state of an input, creating it on first use
+func (in *Input) state(src inputSource) *sourceState {
+	st, ok := in.states[src]
+	if !ok {
+		st = &sourceState{}
+		in.states[src] = st
+	}
+	return st
+}
+
+// Update samples all inputs; call it once at the start of every frame
+func (in *Input) Update() {
+	for src, st := range in.states {
+		if !st.held && !st.wasHeld {
+			delete(in.states, src)
+			continue
+		}
+		st.wasHeld = st.held
+		st.held = false
+		st.consumed = false
+	}
+
+	// Every physical key, plus virtual keys such as Shift that only IsKeyPressed reports
+	in.keys = inpututil.AppendPressedKeys(in.keys[:0])
+	for _, key := range in.keys {
+		in.state(inputSource{Device: deviceKeyboard, Code: int(key)}).held = true
+	}
+	for _, keys := range in.bindings.keys {
+		for _, key := range keys {
+			if ebiten.IsKeyPressed(key) {
+				in.state(inputSource{Device: deviceKeyboard, Code: int(key)}).held = true
+			}
+		}
+	}
+
+	for _, button := range []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle} {
+		if ebiten.IsMouseButtonPressed(button) {
+			in.state(inputSource{Device: deviceMouse, Code: int(button)}).held = true
+		}
+	}
+
+	in.gamepads = in.gamepads[:0]
+	if !in.bindings.gamepad {
+		return
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		in.gamepads = append(in.gamepads, id)
+		for button := ebiten.StandardGamepadButton(0); button <= ebiten.StandardGamepadButtonMax; button++ {
+			if ebiten.IsStandardGamepadButtonPressed(id, button) {
+				in.state(inputSource{Device: deviceGamepadButton, Gamepad: id, Code: int(button)}).held = true
+			}
+		}
+		for _, stick := range stickDirection {
+			if ebiten.StandardGamepadAxisValue(id, stick.Axis)*stick.Sign > in.bindings.deadzone {
+				in.state(stickSource(id, stick.Axis, stick.Sign)).held = true
+			}
+		}
+	}
+}
+
+// stickSource identifies a direction of a gamepad axis
+func stickSource(id ebiten.GamepadID, axis ebiten.StandardGamepadAxis, sign float64) inputSource {
+	code := int(axis) * 2
+	if sign > 0 {
+		code++
+	}
+	return inputSource{Device: deviceGamepadStick, Gamepad: id, Code: code}
+}
+
+// sources lists the tracked states of every input bound to an action
+func (in *Input) sources(action Action) []*sourceState {
+	var states []*sourceState
+	add := func(src inputSource) {
+		if st, ok := in.states[src]; ok {
+			states = append(states, st)
+		}
+	}
+
+	for _, key := range in.bindings.keys[action] {
+		add(inputSource{Device: deviceKeyboard, Code: int(key)})
+	}
+	for _, id := range in.gamepads {
+		for _, button := range in.bindings.buttons[action] {
+			add(inputSource{Device: deviceGamepadButton, Gamepad: id, Code: int(button)})
+		}
+		if stick, ok := stickDirection[action]; ok {
+			add(stickSource(id, stick.Axis, stick.Sign))
+		}
+	}
+	return states
+}
+
+// Held reports whether any input bound to the action is down
+func (in *Input) Held(action Action) bool {
+	for _, st := range in.sources(action) {
+		if st.held {
+			return true
+		}
+	}
+	return false
+}
+
+// JustPressed reports whether an input bound to the action went down this frame and no
+// handler has consumed it yet
+func (in *Input) JustPressed(action Action) bool {
+	for _, st := range in.sources(action) {
+		if st.justPressed() {
+			return true
+		}
+	}
+	return false
+}
+
+// JustReleased reports whether the action's inputs were all let go this frame and no handler
+// has consumed the release yet
+func (in *Input) JustReleased(action Action) bool {
+	released := false
+	for _, st := range in.sources(action) {
+		if st.held {
+			return false
+		}
+		if st.justReleased() {
+			released = true
+		}
+	}
+	return released
+}
+
+// Consume marks this frame's presses and releases of the action's inputs as handled, hiding
+// them from every other action bound to the same inputs
+func (in *Input) Consume(action Action) {
+	for _, st := range in.sources(action) {
+		if st.held != st.wasHeld {
+			st.consumed = true
+		}
+	}
+}
+
+// mouse returns the tracked state of a mouse button
+func (in *Input) mouse(button ebiten.MouseButton) *sourceState {
+	return in.state(inputSource{Device: deviceMouse, Code: int(button)})
+}
+
+// MouseHeld reports whether a mouse button is down
+func (in *Input) MouseHeld(button ebiten.MouseButton) bool {
+	return in.mouse(button).held
+}
+
+// MouseJustPressed reports an unconsumed press of a mouse button this frame
+func (in *Input) MouseJustPressed(button ebiten.MouseButton) bool {
+	return in.mouse(button).justPressed()
+}
+
+// MouseJustReleased reports an unconsumed release of a mouse button this frame
+func (in *Input) MouseJustReleased(button ebiten.MouseButton) bool {
+	return in.mouse(button).justReleased()
+}
+
+// ConsumeMouse marks this frame's press or release of a mouse button as handled
+func (in *Input) ConsumeMouse(button ebiten.MouseButton) {
+	st := in.mouse(button)
+	if st.held != st.wasHeld {
+		st.consumed = true
+	}
+}
+
+// JustPressedKeys returns the keys pressed this frame that no handler has consumed
+func (in *Input) JustPressedKeys() []ebiten.Key {
+	var keys []ebiten.Key
+	for _, key := range in.keys {
+		if in.state(inputSource{Device: deviceKeyboard, Code: int(key)}).justPressed() {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// GamepadConnected reports whether a usable gamepad is connected
+func (in *Input) GamepadConnected() bool {
+	return len(in.gamepads) > 0
+}
+
+// Label lists the keys bound to an action for on-screen hints
+func (in *Input) Label(action Action) string {
+	return in.bindings.Label(action)
+}
+
+// Keys returns the keys bound to an action
+func (in *Input) Keys(action Action) []ebiten.Key {
+	return in.bindings.Keys(action)
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultLanguage is the reference language every other string table falls back to
+const defaultLanguage = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// localeEntry is a translated string, either plain text or a set of plural forms
+type localeEntry struct {
+	Text  string
+	Forms map[string]string // Plural forms keyed by category ("zero", "one", "other")
+}
+
+// UnmarshalJSON accepts either a string or an object of plural forms
+func (e *localeEntry) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.Text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &e.Forms)
+}
+
+// Localizer looks up player-facing strings in the active language's string table
+type Localizer struct {
+	Language string
+	strings  map[string]localeEntry
+	fallback *Localizer      // English table; nil for English itself
+	reported map[string]bool // Missing keys already logged
+}
+
+// AvailableLanguages lists the language codes that have a string table
+func AvailableLanguages() []string {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		return []string{defaultLanguage}
+	}
+
+	var languages []string
+	for _, entry := range entries {
+		languages = append(languages, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// loadStringTable parses the string table of a language
+func loadStringTable(language string) (map[string]localeEntry, error) {
+	data, err := localeFiles.ReadFile("locales/" + language + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown language %q", language)
+	}
+
+	table := make(map[string]localeEntry)
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("parsing %s string table: %v", language, err)
+	}
+	return table, nil
+}
+
+// LoadLocalizer loads a language with English as the fallback for missing keys
+func LoadLocalizer(language string) (*Localizer, error) {
+	englishTable, err := loadStringTable(defaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+	english := &Localizer{
+		Language: defaultLanguage,
+		strings:  englishTable,
+		reported: make(map[string]bool),
+	}
+	if language == defaultLanguage || language == "" {
+		return english, nil
+	}
+
+	table, err := loadStringTable(language)
+	if err != nil {
+		return english, err
+	}
+	return &Localizer{
+		Language: language,
+		strings:  table,
+		fallback: english,
+		reported: make(map[string]bool),
+	}, nil
+}
+
+// lookup finds an entry in this language or the fallback
+func (l *Localizer) lookup(key string) (localeEntry, bool) {
+	if entry, ok := l.strings[key]; ok {
+		return entry, true
+	}
+	if l.fallback != nil {
+		return l.fallback.lookup(key)
+	}
+	return localeEntry{}, false
+}
+
+// missing logs a key that has no translation anywhere (once) and returns the key itself
+func (l *Localizer) missing(key string) string {
+	if !l.reported[key] {
+		l.reported[key] = true
+		log.Printf("Missing translation for %q", key)
+	}
+	return key
+}
+
+// T returns the translated string for key, formatted with args like fmt.Sprintf
+func (l *Localizer) T(key string, args ...interface{}) string {
+	entry, ok := l.lookup(key)
+	if !ok {
+		return l.missing(key)
+	}
+
+	text := entry.Text
+	if entry.Forms != nil {
+		text = entry.Forms["other"]
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// N returns the plural form of key matching count, formatted with args like fmt.Sprintf
+func (l *Localizer) N(key string, count int, args ...interface{}) string {
+	entry, ok := l.lookup(key)
+	if !ok {
+		return l.missing(key)
+	}
+	if entry.Forms == nil {
+		return fmt.Sprintf(entry.Text, args...)
+	}
+
+	text, ok := entry.Forms[pluralCategory(l.Language, count)]
+	if !ok {
+		text = entry.Forms["other"]
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// pluralCategory picks the plural form of a count for a language
+func pluralCategory(language string, count int) string {
+	if count == 0 {
+		return "zero" // Only used when the table defines it, otherwise "other"
+	}
+	switch language {
+	case "fr":
+		if count == 1 {
+			return "one"
+		}
+	default:
+		if count == 1 || count == -1 {
+			return "one"
+		}
+	}
+	return "other"
+}
+
+// Number formats an integer with the language's thousands separator
+func (l *Localizer) Number(n int) string {
+	digits := strconv.Itoa(n)
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(digits, "-")
+
+	separator := l.T("format.thousands_separator")
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteString(separator)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if negative {
+		return "-" + grouped.String()
+	}
+	return grouped.String()
+}
+
+// Money formats an amount of money in the language's currency format
+func (l *Localizer) Money(amount int) string {
+	return l.T("format.money", l.Number(amount))
+}
+
+// formatVerbPattern matches fmt verbs, including explicit argument indexes
+var formatVerbPattern = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+// formatVerbs returns the non-literal fmt verbs of a string in order
+func formatVerbs(text string) []string {
+	var verbs []string
+	for _, verb := range formatVerbPattern.FindAllString(text, -1) {
+		if verb != "%%" {
+			verbs = append(verbs, verb[len(verb)-1:])
+		}
+	}
+	sort.Strings(verbs)
+	return verbs
+}
+
+// CheckLocales compares every string table against English and reports missing keys,
+// unknown keys, missing plural forms and format verbs that don't match. Keys the code uses
+// must be in English too: the names of rebindable keys, and the literal keys passed to T and N in the
+// Go files of sourceDir, if it has any.
+func CheckLocales(sourceDir string) []string {
+	var problems []string
+
+	english, err := loadStringTable(defaultLanguage)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	used, err := usedLocaleKeys(sourceDir)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	for _, binding := range actionBindings {
+		if binding.Field == nil {
+			continue // Gamepad-only actions are not listed on the controls screen
+		}
+		used["action."+binding.Name] = append(used["action."+binding.Name], "actionBindings")
+	}
+	for key, places := range used {
+		if _, ok := english[key]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: key %q used at %s is missing", defaultLanguage, key, strings.Join(places, ", ")))
+		}
+	}
+
+	for _, language := range AvailableLanguages() {
+		if language == defaultLanguage {
+			continue
+		}
+		table, err := loadStringTable(language)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+
+		for key, reference := range english {
+			entry, ok := table[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing key %q", language, key))
+				continue
+			}
+			if (reference.Forms == nil) != (entry.Forms == nil) {
+				problems = append(problems, fmt.Sprintf("%s: %q must be %s like in English", language, key, entryKind(reference)))
+				continue
+			}
+			if entry.Forms != nil {
+				if _, ok := entry.Forms["other"]; !ok {
+					problems = append(problems, fmt.Sprintf("%s: %q has no \"other\" plural form", language, key))
+				}
+			}
+			if fmt.Sprint(formatVerbs(entryText(entry))) != fmt.Sprint(formatVerbs(entryText(reference))) {
+				problems = append(problems, fmt.Sprintf("%s: %q format verbs differ from English", language, key))
+			}
+		}
+
+		for key := range table {
+			if _, ok := english[key]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: unknown key %q", language, key))
+			}
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+// usedLocaleKeys finds the string literal keys passed to T and N in the Go files of dir and
+// returns the places each is used at
+func usedLocaleKeys(dir string) (map[string][]string, error) {
+	used := make(map[string][]string)
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return used, err
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		parsed, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return used, err
+		}
+		ast.Inspect(parsed, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (selector.Sel.Name != "T" && selector.Sel.Name != "N") {
+				return true
+			}
+			literal, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || literal.Kind != token.STRING {
+				return true
+			}
+			key, err := strconv.Unquote(literal.Value)
+			if err != nil {
+				return true
+			}
+			position := fset.Position(literal.Pos())
+			used[key] = append(used[key], fmt.Sprintf("%s:%d", filepath.Base(position.Filename), position.Line))
+			return true
+		})
+	}
+	return used, nil
+}
+
+// entryKind describes whether an entry is plain text or plural forms
+func entryKind(entry localeEntry) string {
+	if entry.Forms != nil {
+		return "plural forms"
+	}
+	return "a plain string"
+}
+
+// entryText returns the text of an entry used to compare format verbs
+func entryText(entry localeEntry) string {
+	if entry.Forms != nil {
+		return entry.Forms["other"]
+	}
+	return entry.Text
+}
@@ -0,0 +1,11 @@
+package main
+
+import "testing"
+
+// TestLocales runs the --check-locales check: every string table matches English, and every
+// key the code uses is in English
+func TestLocales(t *testing.T) {
+	for _, problem := range CheckLocales(".") {
+		t.Error(problem)
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"math/rand"
 	"os"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
+// dpsSmoothing is the per-tick weight used when averaging tower DPS (about a 3 second window)
+const dpsSmoothing = 1.0 / 180.0
+
 type Point struct {
 	X, Y float64
 }
@@ -23,6 +27,7 @@ type Enemy struct {
 	PathIndex  int
 	Alive      bool
 	ReachedEnd bool
+	Anim       AnimState
 }
 
 type Tower struct {
@@ -34,6 +39,35 @@ type Tower struct {
 	Cost     int
 	Type     int
 	Special  map[string]float64 // For special effects like splash radius, slow duration
+
+	// Unbuffed stats; Range, Damage and FireRate are recalculated from these by recalculateAuras
+	BaseRange    float64
+	BaseDamage   int
+	BaseFireRate float64
+
+	// Auras this tower emits, and the bonuses it currently receives from others
+	Auras         []Aura
+	RangeBonus    float64
+	FireRateBonus float64
+	DamageBonus   float64
+	CritChance    float64
+
+	// Progression
+	Level      int
+	TotalSpent int // Purchase price plus all upgrades, used for sell refunds
+	Targeting  TargetingMode
+
+	// Turret and animation state
+	AimAngle float64 // Direction the barrel points, in radians
+	TurnRate float64 // Radians per second; 0 for towers without a turret
+	Recoil   float64 // 1 right after a shot, easing back to 0
+	Anim     AnimState
+
+	// Combat statistics
+	Kills       int
+	DamageDealt int     // Total damage dealt over the tower's lifetime
+	TickDamage  int     // Damage dealt during the current simulation tick
+	DPS         float64 // Smoothed damage per second
 }
 
 type Projectile struct {
@@ -42,38 +76,57 @@ type Projectile struct {
 	Speed    float64
 	Damage   int
 	Active   bool
+	Source   *Tower // Tower that fired this projectile
+	Critical bool
 }
 
 type Game struct {
-	enemies           []*Enemy
-	towers            []*Tower
-	projectiles       []*Projectile
-	path              []Point
-	money             int
-	lives             int
-	wave              int
-	spawnTimer        float64
-	gameOver          bool
-	selectedTowerType int
-	config            *GameConfig
-	enemiesSpawned    int
-	enemiesPerWave    int
-	graphics          *GraphicsManager
-	modeManager       *GameModeManager
-	waveStartTime     float64
-	nextWaveRequested bool
-	spacePressed      bool
-	lastBonusEarned   int
-	bonusDisplayTimer float64
+	enemies            []*Enemy
+	towers             []*Tower
+	projectiles        []*Projectile
+	path               []Point
+	money              int
+	lives              int
+	wave               int
+	spawnTimer         float64
+	gameOver           bool
+	selectedTowerType  int
+	selectedTower      *Tower // Existing tower picked by clicking on it
+	pressOnField       bool   // Left button went down on the map rather than on a UI widget
+	input              *Input
+	configFile         string // Where the controls screen saves rebound keys
+	gamepadCursor      Point  // Grid cell of the gamepad cursor
+	usingGamepad       bool   // The gamepad cursor, not the mouse, picks the build cell
+	cursorHeld         bool
+	cursorRepeatTimer  float64
+	config             *GameConfig
+	enemiesSpawned     int
+	enemiesPerWave     int
+	graphics           *GraphicsManager
+	modeManager        *GameModeManager
+	waveStartTime      float64
+	nextWaveRequested  bool
+	lastBonusEarned    int
+	lastInterestEarned int
+	bonusDisplayTimer  float64
+	gameSpeed          float64 // Simulation ticks per frame (1.0 = real time)
+	tickAccumulator    float64
+	camera             *Camera
+	screenWidth        int // Screen size in pixels, set by Layout
+	screenHeight       int
+	pixelScale         float64       // Screen pixels per layout pixel (HiDPI and letterbox scaling)
+	worldImage         *ebiten.Image // The map is drawn here, then onto the screen through the camera
+	renderQueue        RenderQueue   // Draw commands of the current frame, drawn layer by layer
+	screenshotPending  bool          // Save the next finished frame as a PNG
+	ui                 *UI
+	screens            *gameScreens
+	loc                *Localizer
+	theme              *Theme
 }
 
-func NewGame(config *GameConfig) *Game {
-	cellSize := config.GridSize
-	mapWidth := config.WindowWidth / cellSize
-	mapHeight := config.WindowHeight / cellSize
-
-	// Create a simple path that adapts to screen size
-	path := []Point{
+// defaultPath creates a simple winding path that adapts to the map size
+func defaultPath(mapWidth, mapHeight int) []Point {
+	return []Point{
 		{0, float64(mapHeight / 2)},
 		{float64(mapWidth / 4), float64(mapHeight / 2)},
 		{float64(mapWidth / 4), float64(mapHeight / 4)},
@@ -83,6 +136,26 @@ func NewGame(config *GameConfig) *Game {
 		{float64(3 * mapWidth / 4), float64(mapHeight / 3)},
 		{float64(mapWidth), float64(mapHeight / 3)},
 	}
+}
+
+func NewGame(config *GameConfig) *Game {
+	mapWidth, mapHeight := config.MapSize()
+	path := defaultPath(mapWidth, mapHeight)
+
+	loc, err := LoadLocalizer(config.Language)
+	if err != nil {
+		log.Printf("Error loading language: %v, using English", err)
+	}
+
+	theme, err := LoadTheme(config.Theme)
+	if err != nil {
+		log.Printf("Error loading theme: %v, using the default colors for those", err)
+	}
+
+	bindings, inputErr := NewInputMap(config)
+	if inputErr != nil {
+		log.Printf("Error in controls: %v, using default keys for those actions", inputErr)
+	}
 
 	game := &Game{
 		enemies:           []*Enemy{},
@@ -93,12 +166,35 @@ func NewGame(config *GameConfig) *Game {
 		lives:             config.StartingLives,
 		wave:              1,
 		spawnTimer:        0,
-		selectedTowerType: 1,
+		selectedTowerType: TowerBasic,
 		config:            config,
 		enemiesSpawned:    0,
 		enemiesPerWave:    config.GetEnemiesInWave(1),
-		graphics:          NewGraphicsManager(),
+		graphics:          NewGraphicsManager(theme, config.MaxParticles),
 		modeManager:       NewGameModeManagerWithDebug(config.DebugMode, config),
+		gameSpeed:         1.0,
+		input:             NewInput(bindings),
+		configFile:        "config.json",
+		gamepadCursor:     Point{float64(mapWidth / 2), float64(mapHeight / 2)},
+		camera:            NewCamera(config),
+		screenWidth:       config.WindowWidth,
+		screenHeight:      config.WindowHeight,
+		pixelScale:        1,
+		worldImage:        ebiten.NewImage(mapWidth*config.GridSize, mapHeight*config.GridSize),
+		ui:                NewUI(NewFontManager(config.UIScale), theme),
+		loc:               loc,
+		theme:             theme,
+	}
+	if err := game.graphics.LoadSprites(config.AssetsDir); err != nil {
+		log.Printf("Error loading sprites: %v, drawing those with vector graphics", err)
+	}
+	if err := game.graphics.LoadEmitters(config.AssetsDir); err != nil {
+		log.Printf("Error loading particle emitters: %v, those effects are left out", err)
+	}
+	game.ui.Fonts.LogMissing = config.DebugMode
+	game.screens = newGameScreens(game)
+	if inputErr != nil {
+		game.modeManager.ControlsStatus = inputErr.Error()
 	}
 
 	// If debug mode auto-started playing mode, setup the first level
@@ -110,11 +206,32 @@ func NewGame(config *GameConfig) *Game {
 }
 
 func (g *Game) Update() error {
+	g.input.Update()
+	if g.input.JustPressed(ActionScreenshot) {
+		g.screenshotPending = true
+	}
+
+	// Let the UI widgets of the current screen handle the mouse first
+	g.ui.BeginFrame(g.input)
+	g.ui.Update(g.screens.roots(g.modeManager.CurrentState)...)
+	if g.ui.JustPressed {
+		g.pressOnField = g.input.MouseJustPressed(ebiten.MouseButtonLeft)
+	}
+
 	// Update game mode system
 	if err := g.modeManager.Update(g); err != nil {
 		return err
 	}
 
+	// Tactical pause: the simulation is frozen (apart from frame steps) but building is still allowed
+	if g.modeManager.CurrentState == StatePaused {
+		g.handleSpeedInput()
+		g.handleFrameStep()
+		g.handleCameraInput()
+		g.handleBuildInput()
+		return nil
+	}
+
 	// Only update game logic if we're in playing state
 	if g.modeManager.CurrentState != StatePlaying {
 		return nil
@@ -124,18 +241,85 @@ func (g *Game) Update() error {
 		return nil
 	}
 
-	// Handle spacebar for next wave (only when all enemies are dead and spawned)
-	spaceCurrentlyPressed := ebiten.IsKeyPressed(ebiten.KeySpace)
-	if spaceCurrentlyPressed && !g.spacePressed && len(g.enemies) == 0 && g.enemiesSpawned >= g.enemiesPerWave {
+	// Handle the next wave key (only when all enemies are dead and spawned)
+	if g.input.JustPressed(ActionNextWave) && len(g.enemies) == 0 && g.enemiesSpawned >= g.enemiesPerWave {
 		g.nextWaveRequested = true
 		if g.config.DebugMode {
-			fmt.Printf("Next wave requested via spacebar!\n")
+			fmt.Printf("Next wave requested via %s!\n", g.input.Label(ActionNextWave))
+		}
+	}
+
+	// Handle game speed changes
+	g.handleSpeedInput()
+
+	if g.bonusDisplayTimer > 0 {
+		g.bonusDisplayTimer -= 1.0 / 60.0
+	}
+
+	// Advance the simulation according to the current game speed
+	g.tickAccumulator += g.gameSpeed
+	for g.tickAccumulator >= 1 {
+		g.tickAccumulator--
+		g.updateSimulation()
+		if g.gameOver {
+			break
 		}
 	}
-	g.spacePressed = spaceCurrentlyPressed
 
-	// Update particle system
+	g.handleCameraInput()
+	g.handleBuildInput()
+
+	return nil
+}
+
+// handleBuildInput handles tower selection and placement; it runs while playing and while paused
+func (g *Game) handleBuildInput() {
+	g.handleGamepadInput()
+
+	// Select an existing tower or place a new one when a field click is released
+	if g.input.MouseJustReleased(ebiten.MouseButtonLeft) && g.pressOnField {
+		g.pressOnField = false
+		if gridX, gridY, ok := g.hoveredCell(); ok {
+			if tower := g.towerAt(gridX, gridY); tower != nil {
+				g.selectedTower = tower
+			} else {
+				g.placeTower(gridX, gridY)
+			}
+		}
+	}
+
+	// Right click clears the tower selection
+	if g.input.MouseJustPressed(ebiten.MouseButtonRight) {
+		g.selectedTower = nil
+	}
+
+	// Selected tower hotkeys: upgrade, sell and cycle targeting
+	if g.selectedTower != nil {
+		if g.input.JustPressed(ActionUpgrade) {
+			g.upgradeTower(g.selectedTower)
+		}
+		if g.input.JustPressed(ActionTargeting) {
+			g.selectedTower.Targeting = g.selectedTower.Targeting.Next()
+		}
+		if g.input.JustPressed(ActionSell) {
+			g.sellTower(g.selectedTower)
+		}
+	}
+
+	// Handle key input for tower selection
+	for towerType := 1; towerType <= towerTypeCount; towerType++ {
+		if g.input.JustPressed(towerSelectAction(towerType)) {
+			g.selectedTowerType = towerType
+			break
+		}
+	}
+}
+
+// updateSimulation advances enemies, towers, projectiles and particles by one tick
+func (g *Game) updateSimulation() {
+	// Update particle system and emit trails behind enemies and projectiles
 	g.graphics.ParticleSystem.Update()
+	g.graphics.EmitTrails(g.enemies, g.projectiles, g.config)
 
 	// Spawn enemies
 	g.spawnTimer += 1.0 / 60.0
@@ -167,16 +351,19 @@ func (g *Game) Update() error {
 		}
 
 		g.moveEnemy(enemy)
+		// Enemies walk faster as they move faster
+		enemy.Anim.Advance(g.graphics.EnemySprite, enemy.Speed/60.0)
 
 		if enemy.ReachedEnd {
-			g.lives--
+			if !g.hasInfiniteResources() {
+				g.lives--
+			}
 			g.enemies = append(g.enemies[:i], g.enemies[i+1:]...)
 			if g.lives <= 0 {
 				g.gameOver = true
 			}
 		} else if enemy.Health <= 0 {
-			// Create explosion effect when enemy dies
-			g.graphics.CreateExplosion(enemy.Position, 3, g.config)
+			g.graphics.EmitEffect("enemy", effectDeath, enemy.Position, Point{}, g.config)
 			g.money += g.config.EnemyReward
 			g.enemies = append(g.enemies[:i], g.enemies[i+1:]...)
 			if g.config.DebugMode {
@@ -188,15 +375,23 @@ func (g *Game) Update() error {
 		}
 	}
 
-	// Update towers
+	// Update towers (support structures never fire); turrets track their target every tick
 	for _, tower := range g.towers {
+		tower.Anim.Advance(g.graphics.TowerSprites[tower.Type], 1.0/60.0)
+		tower.Recoil = math.Max(0, tower.Recoil-1.0/60.0/recoilTime)
+		if g.config.IsSupportStructure(tower.Type) {
+			continue
+		}
+
 		tower.LastFire += 1.0 / 60.0
-		if tower.LastFire >= tower.FireRate {
-			target := g.findNearestEnemy(tower)
-			if target != nil {
-				g.fireTower(tower, target)
-				tower.LastFire = 0
-			}
+		target := g.findTarget(tower)
+		if target == nil {
+			continue
+		}
+		tower.aimAt(target.Position, 1.0/60.0)
+		if tower.LastFire >= tower.FireRate && g.canFire(tower, target) {
+			g.fireTower(tower, target)
+			tower.LastFire = 0
 		}
 	}
 
@@ -214,51 +409,33 @@ func (g *Game) Update() error {
 		}
 	}
 
-	// Handle mouse input for tower placement
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		cellSize := g.config.GridSize
-		gridX := x / cellSize
-		gridY := y / cellSize
-		g.placeTower(float64(gridX), float64(gridY))
-	}
-
-	// Handle key input for tower selection (only in playing state)
-	if g.modeManager.CurrentState == StatePlaying {
-		if ebiten.IsKeyPressed(ebiten.Key1) {
-			g.selectedTowerType = 1
-		} else if ebiten.IsKeyPressed(ebiten.Key2) {
-			g.selectedTowerType = 2
-		} else if ebiten.IsKeyPressed(ebiten.Key3) {
-			g.selectedTowerType = 3
-		} else if ebiten.IsKeyPressed(ebiten.Key4) {
-			g.selectedTowerType = 4
-		} else if ebiten.IsKeyPressed(ebiten.Key5) {
-			g.selectedTowerType = 5
-		} else if ebiten.IsKeyPressed(ebiten.Key6) {
-			g.selectedTowerType = 6
-		}
+	// Fold this tick's damage into each tower's running DPS
+	for _, tower := range g.towers {
+		tower.DPS += (float64(tower.TickDamage)*60.0 - tower.DPS) * dpsSmoothing
+		tower.TickDamage = 0
 	}
 
 	// Update wave timer for bonus calculation
 	g.waveStartTime += 1.0 / 60.0
-
-	return nil
 }
 
 func (g *Game) spawnEnemy() {
+	g.spawnEnemyWith(g.config.GetEnemyHealth(g.wave), g.config.EnemySpeed)
+}
+
+// spawnEnemyWith spawns an enemy at the start of the path with the given stats
+func (g *Game) spawnEnemyWith(health int, speed float64) {
 	if len(g.path) == 0 {
 		return
 	}
 
 	cellSize := float64(g.config.GridSize)
-	health := g.config.GetEnemyHealth(g.wave)
 
 	enemy := &Enemy{
 		Position:  Point{g.path[0].X*cellSize + cellSize/2, g.path[0].Y*cellSize + cellSize/2},
 		Health:    health,
 		MaxHealth: health,
-		Speed:     g.config.EnemySpeed,
+		Speed:     speed,
 		PathIndex: 0,
 		Alive:     true,
 	}
@@ -297,58 +474,87 @@ func (g *Game) moveEnemy(enemy *Enemy) {
 }
 
 func (g *Game) placeTower(gridX, gridY float64) {
-	// Check if position is valid (not on path and not occupied)
-	if g.isOnPath(gridX, gridY) || g.isTowerAt(gridX, gridY) {
+	// Check if position is valid (not on path, not occupied and affordable)
+	if valid, _ := g.placementCheck(gridX, gridY); !valid {
 		return
 	}
 
 	cellSize := float64(g.config.GridSize)
 	cost, damage, rangeVal, fireRate := g.config.GetTowerStats(g.selectedTowerType)
 
-	if g.money >= cost {
-		tower := &Tower{
-			Position: Point{gridX*cellSize + cellSize/2, gridY*cellSize + cellSize/2},
-			Range:    rangeVal,
-			Damage:   damage,
-			FireRate: fireRate,
-			Cost:     cost,
-			Type:     g.selectedTowerType,
-			Special:  make(map[string]float64),
-		}
-
-		// Set special properties based on tower type
-		switch g.selectedTowerType {
-		case 5: // Splash Tower
-			tower.Special["splash_radius"] = g.config.SplashRadius
-		case 6: // Slow Tower
-			tower.Special["slow_effect"] = g.config.SlowEffect
-			tower.Special["slow_duration"] = g.config.SlowDuration
-		}
-
+	tower := &Tower{
+		Position:     Point{gridX*cellSize + cellSize/2, gridY*cellSize + cellSize/2},
+		Range:        rangeVal,
+		Damage:       damage,
+		FireRate:     fireRate,
+		Cost:         cost,
+		Type:         g.selectedTowerType,
+		Special:      make(map[string]float64),
+		BaseRange:    rangeVal,
+		BaseDamage:   damage,
+		BaseFireRate: fireRate,
+		Auras:        g.config.GetTowerAuras(g.selectedTowerType),
+		Level:        1,
+		TotalSpent:   cost,
+		AimAngle:     -math.Pi / 2,
+		TurnRate:     g.config.GetTowerTurnRate(g.selectedTowerType),
+	}
+
+	// Set special properties based on tower type
+	switch g.selectedTowerType {
+	case TowerSplash:
+		tower.Special["splash_radius"] = g.config.SplashRadius
+	case TowerSlow:
+		tower.Special["slow_effect"] = g.config.SlowEffect
+		tower.Special["slow_duration"] = g.config.SlowDuration
+	}
+
+	if !g.hasInfiniteResources() {
 		g.money -= cost
-		g.towers = append(g.towers, tower)
 	}
+	g.towers = append(g.towers, tower)
+	g.recalculateAuras()
+}
+
+// hasInfiniteResources reports whether money and lives are unlimited (sandbox mode)
+func (g *Game) hasInfiniteResources() bool {
+	return g.modeManager.CurrentMode == GameModeSandbox
+}
+
+// paysInterest reports whether unspent money earns interest between waves; sandbox money is
+// unlimited, so it earns none
+func (g *Game) paysInterest() bool {
+	return g.config.InterestEnabled && !g.hasInfiniteResources()
 }
 
 func (g *Game) isOnPath(gridX, gridY float64) bool {
-	for _, point := range g.path {
+	// Check the waypoints and every cell the path passes through between them
+	for i, point := range g.path {
 		if point.X == gridX && point.Y == gridY {
 			return true
 		}
+		if i > 0 && pointOnSegment(gridX, gridY, g.path[i-1], point) {
+			return true
+		}
 	}
 	return false
 }
 
 func (g *Game) isTowerAt(gridX, gridY float64) bool {
+	return g.towerAt(gridX, gridY) != nil
+}
+
+// towerAt returns the tower occupying a grid cell, or nil
+func (g *Game) towerAt(gridX, gridY float64) *Tower {
 	cellSize := float64(g.config.GridSize)
 	for _, tower := range g.towers {
 		towerGridX := (tower.Position.X - cellSize/2) / cellSize
 		towerGridY := (tower.Position.Y - cellSize/2) / cellSize
 		if math.Abs(towerGridX-gridX) < 0.1 && math.Abs(towerGridY-gridY) < 0.1 {
-			return true
+			return tower
 		}
 	}
-	return false
+	return nil
 }
 
 func (g *Game) findNearestEnemy(tower *Tower) *Enemy {
@@ -379,28 +585,18 @@ func (g *Game) applyProjectileDamage(proj *Projectile) {
 		return
 	}
 
-	// Find the tower that fired this projectile to get special effects
-	var sourceTower *Tower
-	for _, tower := range g.towers {
-		// This is simplified - in a real game you'd track projectile source
-		if tower.Type >= 3 { // For now, assume special towers
-			sourceTower = tower
-			break
-		}
-	}
+	// Special effects come from the tower that fired this projectile
+	sourceTower := proj.Source
 
 	// Apply base damage
-	proj.Target.Health -= proj.Damage
-	if proj.Target.Health <= 0 {
-		proj.Target.Alive = false
-	}
+	g.damageEnemy(proj.Target, proj.Damage, sourceTower)
 
 	// Apply special effects if source tower has them
 	if sourceTower != nil {
 		switch sourceTower.Type {
-		case 5: // Splash Tower - damage nearby enemies
+		case TowerSplash: // Damage nearby enemies
 			g.applySplashDamage(proj.Target.Position, sourceTower)
-		case 6: // Slow Tower - apply slow effect
+		case TowerSlow: // Apply slow effect
 			g.applySlowEffect(proj.Target, sourceTower)
 		}
 	}
@@ -421,14 +617,27 @@ func (g *Game) applySplashDamage(center Point, tower *Tower) {
 		distance := math.Sqrt(dx*dx + dy*dy)
 
 		if distance <= radius {
-			enemy.Health -= int(splashDamage)
-			if enemy.Health <= 0 {
-				enemy.Alive = false
-			}
-			// Create small explosion for splash effect
-			g.graphics.CreateExplosion(enemy.Position, 1, g.config)
+			g.damageEnemy(enemy, int(splashDamage), tower)
+			g.graphics.EmitEffect(projectileEffectKey(tower.Type), effectSplash, enemy.Position, Point{}, g.config)
+		}
+	}
+}
+
+// damageEnemy applies damage to an enemy and credits it to the tower that dealt it
+func (g *Game) damageEnemy(enemy *Enemy, damage int, source *Tower) {
+	if source != nil && enemy.Health > 0 {
+		dealt := min(damage, enemy.Health)
+		source.DamageDealt += dealt
+		source.TickDamage += dealt
+		if damage >= enemy.Health {
+			source.Kills++
 		}
 	}
+
+	enemy.Health -= damage
+	if enemy.Health <= 0 {
+		enemy.Alive = false
+	}
 }
 
 // applySlowEffect applies slowing effect to enemy
@@ -442,14 +651,28 @@ func (g *Game) applySlowEffect(enemy *Enemy, tower *Tower) {
 }
 
 func (g *Game) fireTower(tower *Tower, target *Enemy) {
+	// Roll for a critical hit using the crit chance granted by auras
+	damage := tower.Damage
+	critical := tower.CritChance > 0 && rand.Float64() < tower.CritChance
+	if critical {
+		damage = int(float64(damage) * g.config.CritMultiplier)
+	}
+
 	projectile := &Projectile{
 		Position: Point{tower.Position.X, tower.Position.Y},
 		Target:   target,
 		Speed:    5.0,
-		Damage:   tower.Damage,
+		Damage:   damage,
 		Active:   true,
+		Source:   tower,
+		Critical: critical,
 	}
 	g.projectiles = append(g.projectiles, projectile)
+
+	// Kick the barrel back and play the sprite sheet's firing animation, if it has one
+	tower.Recoil = 1
+	tower.Anim.Play(g.graphics.TowerSprites[tower.Type], fireAnimation)
+	g.graphics.EmitEffect(spriteKey(tower.Type), effectFire, tower.muzzlePosition(), Point{}, g.config)
 }
 
 func (g *Game) moveProjectile(proj *Projectile) {
@@ -463,8 +686,12 @@ func (g *Game) moveProjectile(proj *Projectile) {
 	distance := math.Sqrt(dx*dx + dy*dy)
 
 	if distance < 5 {
-		// Hit target - create impact effect
-		g.graphics.CreateExplosion(proj.Target.Position, 2, g.config)
+		// Hit target - create impact effect (bigger for critical hits)
+		event := effectImpact
+		if proj.Critical {
+			event = effectCriticalImpact
+		}
+		g.graphics.EmitEffect(proj.effectKey(), event, proj.Target.Position, Point{}, g.config)
 
 		// Apply damage and special effects based on projectile type
 		g.applyProjectileDamage(proj)
@@ -480,90 +707,119 @@ func (g *Game) moveProjectile(proj *Projectile) {
 func (g *Game) Draw(screen *ebiten.Image) {
 	// Handle different drawing based on game state
 	switch g.modeManager.CurrentState {
-	case StateMenu:
-		g.modeManager.DrawMenu(screen, g.config)
-		return
+	case StateMenu, StateControls:
+		g.modeManager.DrawMenu(screen, g)
 	case StatePlaying, StatePaused, StateGameOver, StateVictory:
 		// Draw game content
 		g.drawGameContent(screen)
 
-		// Draw game state overlays
+		// Draw game state overlays, then the screen-space labels over the map
 		g.modeManager.DrawGameState(screen, g)
+		g.renderQueue.Draw(screen, LayerUI, LayerUI)
 	}
+
+	// Draw the widgets of the current screen on top
+	g.ui.Draw(screen, g.screens.roots(g.modeManager.CurrentState)...)
+
+	g.takeScreenshot(screen)
 }
 
+// drawGameContent draws the map and everything on it into the world image, then shows it
+// through the camera
 func (g *Game) drawGameContent(screen *ebiten.Image) {
-	// Draw enhanced textured background
-	g.graphics.DrawTexturedBackground(screen, g.config, g.path)
+	screen.Fill(g.theme.Background)
+	world := g.worldImage
+	world.Clear()
+	g.drawWorld(world)
 
-	// Draw enhanced towers with their types
-	for _, tower := range g.towers {
-		g.graphics.DrawEnhancedTower(screen, tower, tower.Type, g.config)
+	op := &ebiten.DrawImageOptions{GeoM: g.camera.GeoM()}
+	if g.camera.Scale() != 1 {
+		op.Filter = ebiten.FilterLinear
 	}
+	screen.DrawImage(world, op)
+}
 
-	// Draw enhanced enemies
-	for _, enemy := range g.enemies {
-		g.graphics.DrawEnhancedEnemy(screen, enemy, g.config)
-	}
+// drawWorld queues this frame's draw commands and draws the world layers onto target; the
+// UI layer is left queued for after the camera
+func (g *Game) drawWorld(target *ebiten.Image) {
+	g.renderQueue.Reset()
+	g.submitScene(&g.renderQueue)
+	g.renderQueue.Draw(target, LayerGround, lastWorldLayer)
+}
 
-	// Draw enhanced projectiles
-	for _, proj := range g.projectiles {
-		g.graphics.DrawEnhancedProjectile(screen, proj, g.config)
-	}
+// submitScene has everything on the map submit its draw commands; the queue puts them in
+// layer order, so the order here does not decide what covers what
+func (g *Game) submitScene(queue *RenderQueue) {
+	queue.Submit(LayerGround, 0, func(target *ebiten.Image) {
+		g.graphics.DrawTexturedBackground(target, g.config, g.path)
+	})
 
-	// Draw particle effects
-	g.graphics.ParticleSystem.Draw(screen)
+	for _, tower := range g.towers {
+		g.graphics.SubmitTower(queue, tower, g.config)
+	}
 
-	// Draw UI with all tower types (only if playing)
-	if g.modeManager.CurrentState == StatePlaying {
-		cost1, _, _, _ := g.config.GetTowerStats(1)
-		cost2, _, _, _ := g.config.GetTowerStats(2)
-		cost3, _, _, _ := g.config.GetTowerStats(3)
-		cost4, _, _, _ := g.config.GetTowerStats(4)
-		cost5, _, _, _ := g.config.GetTowerStats(5)
-		cost6, _, _, _ := g.config.GetTowerStats(6)
+	// Aura radii and effective stats for the selected tower
+	if g.selectedTower != nil {
+		g.graphics.SubmitAuraRadius(queue, g.selectedTower, g.towers)
+	}
 
-		// Add wave progress feedback
-		waveStatus := ""
-		if g.enemiesSpawned < g.enemiesPerWave {
-			waveStatus = fmt.Sprintf(" - Spawning: %d/%d", g.enemiesSpawned, g.enemiesPerWave)
-		} else if len(g.enemies) > 0 {
-			waveStatus = fmt.Sprintf(" - Kill remaining: %d", len(g.enemies))
-		} else if len(g.enemies) == 0 && g.enemiesSpawned >= g.enemiesPerWave {
-			waveStatus = " - Press SPACE for next wave (BONUS!)"
-		}
+	// Preview the selected tower type under the cursor
+	state := g.modeManager.CurrentState
+	if state == StatePlaying || state == StatePaused {
+		g.submitPlacementGhost(queue)
+		g.submitGamepadCursor(queue)
+	}
 
-		uiText := fmt.Sprintf("Money: $%d | Lives: %d | Wave: %d%s\n\n"+
-			"1: Basic ($%d)  2: Heavy ($%d)  3: Sniper ($%d)\n"+
-			"4: Laser ($%d)  5: Splash ($%d)  6: Slow ($%d)\n\n"+
-			"Selected: %s Tower\n"+
-			"Click to place towers and defend against enemies!\n"+
-			"Press SPACE when wave complete for bonus money!",
-			g.money, g.lives, g.wave, waveStatus,
-			cost1, cost2, cost3, cost4, cost5, cost6,
-			g.config.GetTowerName(g.selectedTowerType))
+	for _, enemy := range g.enemies {
+		g.graphics.SubmitEnemy(queue, enemy, g.config)
+	}
 
-		// Add bonus display if recently earned
-		if g.bonusDisplayTimer > 0 {
-			uiText += fmt.Sprintf("\n\n🎉 EARLY WAVE BONUS: +$%d!", g.lastBonusEarned)
-			g.bonusDisplayTimer -= 1.0 / 60.0
-		}
+	for _, proj := range g.projectiles {
+		g.graphics.SubmitProjectile(queue, proj)
+	}
 
-		if g.config.ShowFPS {
-			uiText += fmt.Sprintf("\nFPS: %.1f", ebiten.ActualFPS())
-		}
+	// Particles go over the muzzle flashes of their layer
+	queue.Submit(LayerEffects, math.MaxFloat64, g.graphics.ParticleSystem.Draw)
 
-		ebitenutil.DebugPrint(screen, uiText)
+	if state == StatePlaying && g.modeManager.CurrentMode == GameModeSandbox {
+		g.submitSandboxDPS(queue)
 	}
 }
 
-func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return g.config.WindowWidth, g.config.WindowHeight
-}
-
 func main() {
 	// Determine config file to use
 	configFile := "config.json"
+	if len(os.Args) > 1 && os.Args[1] == "--check-locales" {
+		problems := CheckLocales(".")
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+		if len(problems) > 0 {
+			os.Exit(1)
+		}
+		fmt.Printf("All string tables match English (%s)\n", strings.Join(AvailableLanguages(), ", "))
+		return
+	}
+	if len(os.Args) > 1 && (os.Args[1] == "--render" || os.Args[1] == "--render-check") {
+		// Offscreen rendering of a scene file, optionally with a config file
+		if len(os.Args) < 4 || len(os.Args) > 5 {
+			fmt.Fprintf(os.Stderr, "usage: %s %s scene.json image.png [config.json]\n", os.Args[0], os.Args[1])
+			os.Exit(2)
+		}
+		renderConfig := DefaultConfig()
+		if len(os.Args) > 4 {
+			loaded, err := LoadConfig(os.Args[4])
+			if err != nil {
+				log.Fatal(err)
+			}
+			renderConfig = loaded
+		}
+		renderConfig.ValidateConfig()
+		if err := RunRender(os.Args[2], os.Args[3], renderConfig, os.Args[1] == "--render-check"); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	if len(os.Args) > 1 {
 		configFile = os.Args[1]
 	}
@@ -581,6 +837,10 @@ func main() {
 	// Set window properties
 	ebiten.SetWindowSize(config.WindowWidth, config.WindowHeight)
 	ebiten.SetWindowTitle(config.WindowTitle)
+	if config.Resizable {
+		ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+		ebiten.SetWindowSizeLimits(minWindowWidth, minWindowHeight, -1, -1)
+	}
 	if config.Fullscreen {
 		ebiten.SetFullscreen(true)
 	}
@@ -589,6 +849,7 @@ func main() {
 	}
 
 	game := NewGame(config)
+	game.configFile = configFile
 	if err := ebiten.RunGame(game); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TestMain runs the tests and benchmarks inside the game loop, since Ebiten only draws to
+// offscreen images and reads their pixels there. On Linux without a display, run them under
+// xvfb-run.
+func TestMain(m *testing.M) {
+	code := 0
+	if err := runInGameLoop("Tests", func() error {
+		code = m.Run()
+		return nil
+	}); err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(code)
+}
+
+// flushDrawing makes Ebiten submit the queued draw commands for an image by reading a pixel,
+// so a benchmark's time includes the GPU work of a frame
+func flushDrawing(img *ebiten.Image) {
+	img.At(0, 0)
+}
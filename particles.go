@@ -0,0 +1,117 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// particleImageRadius is the radius of the disc image particles are drawn with
+const particleImageRadius = 16
+
+// Particle represents a visual effect particle; its emitter decides how it looks over its life
+type Particle struct {
+	Position Point
+	Velocity Point // Pixels per tick
+	Life     float64
+	MaxLife  float64
+	Gravity  float64
+	Emitter  *Emitter
+}
+
+// ParticleSystem keeps particles in a fixed-size pool allocated up front. The live particles
+// are the first count entries; a dying particle is replaced by the last live one, so spawning
+// and removing never allocate or shift the pool.
+type ParticleSystem struct {
+	particles []Particle
+	count     int
+	Dropped   int           // Particles not spawned because the pool was full
+	disc      *ebiten.Image // White disc scaled and tinted for every particle
+}
+
+// NewParticleSystem creates a particle system holding at most capacity particles
+func NewParticleSystem(capacity int) *ParticleSystem {
+	return &ParticleSystem{particles: make([]Particle, capacity)}
+}
+
+// Spawn adds a copy of a particle; it is dropped when the budget is used up
+func (ps *ParticleSystem) Spawn(particle Particle) bool {
+	if ps.count >= len(ps.particles) {
+		ps.Dropped++
+		return false
+	}
+	ps.particles[ps.count] = particle
+	ps.count++
+	return true
+}
+
+// Count returns the number of live particles
+func (ps *ParticleSystem) Count() int {
+	return ps.count
+}
+
+// Capacity returns the particle budget
+func (ps *ParticleSystem) Capacity() int {
+	return len(ps.particles)
+}
+
+// Clear removes every particle
+func (ps *ParticleSystem) Clear() {
+	ps.count = 0
+}
+
+// Update moves every particle by one tick and removes the ones whose life ran out
+func (ps *ParticleSystem) Update() {
+	for i := 0; i < ps.count; {
+		particle := &ps.particles[i]
+
+		// Update particle physics
+		particle.Position.X += particle.Velocity.X
+		particle.Position.Y += particle.Velocity.Y
+		particle.Velocity.Y += particle.Gravity
+
+		// Update life; a dead particle's slot takes the last live one, which is updated next
+		particle.Life -= 1.0 / 60.0
+		if particle.Life <= 0 {
+			ps.count--
+			ps.particles[i] = ps.particles[ps.count]
+			continue
+		}
+		i++
+	}
+}
+
+// Draw renders all particles, each with its emitter's color, size and blend mode at its age
+func (ps *ParticleSystem) Draw(screen *ebiten.Image) {
+	if ps.disc == nil {
+		size := 2 * particleImageRadius
+		ps.disc = ebiten.NewImage(size, size)
+		vector.DrawFilledCircle(ps.disc, particleImageRadius, particleImageRadius, particleImageRadius, color.White, true)
+	}
+
+	op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+	for i := 0; i < ps.count; i++ {
+		particle := &ps.particles[i]
+		emitter := particle.Emitter
+		age := 1 - particle.Life/particle.MaxLife
+
+		clr := emitter.colorAt(age)
+		if emitter.fade {
+			clr = fadeAlpha(clr, particle.Life/particle.MaxLife)
+		}
+		scale := float64(emitter.sizeAt(age)) / particleImageRadius
+
+		op.GeoM.Reset()
+		op.GeoM.Translate(-particleImageRadius, -particleImageRadius)
+		op.GeoM.Scale(scale, scale)
+		op.GeoM.Translate(particle.Position.X, particle.Position.Y)
+		op.ColorScale.Reset()
+		op.ColorScale.ScaleWithColor(clr)
+		op.Blend = ebiten.BlendSourceOver
+		if emitter.additive {
+			op.Blend = ebiten.BlendLighter
+		}
+		screen.DrawImage(ps.disc, op)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// particleCounts are the pool sizes the particle benchmarks run with
+var particleCounts = []int{1000, 5000, 20000}
+
+// benchEmitter is the emitter of benchmark particles: explosion debris that shrinks and fades
+var benchEmitter, _ = newEmitter("bench", EmitterDef{
+	Mode:    emitterBurst,
+	Count:   1,
+	Life:    [2]float64{1, 1},
+	Colors:  []string{"particle.explosion"},
+	Size:    [2]float64{2, 1},
+	Gravity: 180,
+	Fade:    true,
+}, DefaultTheme())
+
+// benchParticle returns a particle spread over a 1600x1200 field by index
+func benchParticle(i int, life float64) Particle {
+	angle := float64(i) * 0.618 * 2 * math.Pi
+	return Particle{
+		Position: Point{float64(i*37%1600) + 0.5, float64(i*91%1200) + 0.5},
+		Velocity: Point{math.Cos(angle), math.Sin(angle)},
+		Life:     life,
+		MaxLife:  life,
+		Gravity:  0.05,
+		Emitter:  benchEmitter,
+	}
+}
+
+// BenchmarkParticleUpdate moves a full pool of particles that never expire
+func BenchmarkParticleUpdate(b *testing.B) {
+	for _, count := range particleCounts {
+		count := count
+		b.Run(strconv.Itoa(count), func(b *testing.B) {
+			ps := NewParticleSystem(count)
+			for i := 0; i < count; i++ {
+				ps.Spawn(benchParticle(i, math.MaxFloat64))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				ps.Update()
+			}
+		})
+	}
+}
+
+// BenchmarkParticleChurn keeps the pool at its budget while particles die and are replaced
+// every tick, as during heavy fighting
+func BenchmarkParticleChurn(b *testing.B) {
+	for _, count := range particleCounts {
+		count := count
+		b.Run(strconv.Itoa(count), func(b *testing.B) {
+			ps := NewParticleSystem(count)
+			for i := 0; i < count; i++ {
+				ps.Spawn(benchParticle(i, float64(1+i%60)/60))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				ps.Update()
+				for i := ps.Count(); i < count; i++ {
+					ps.Spawn(benchParticle(n+i, 1))
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkParticleDraw draws a full pool of particles onto a screen-sized image
+func BenchmarkParticleDraw(b *testing.B) {
+	for _, count := range particleCounts {
+		count := count
+		b.Run(strconv.Itoa(count), func(b *testing.B) {
+			ps := NewParticleSystem(count)
+			for i := 0; i < count; i++ {
+				ps.Spawn(benchParticle(i, 1))
+			}
+			target := ebiten.NewImage(1600, 1200)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				target.Clear()
+				ps.Draw(target)
+				flushDrawing(target)
+			}
+		})
+	}
+}
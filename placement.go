@@ -0,0 +1,86 @@
+package main
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// placementCheck reports whether the selected tower type can be built on a grid cell,
+// along with the localization key of a short reason when it cannot
+func (g *Game) placementCheck(gridX, gridY float64) (bool, string) {
+	if g.isOnPath(gridX, gridY) {
+		return false, "placement.path"
+	}
+	if g.isTowerAt(gridX, gridY) {
+		return false, "placement.occupied"
+	}
+	cost, _, _, _ := g.config.GetTowerStats(g.selectedTowerType)
+	if g.money < cost && !g.hasInfiniteResources() {
+		return false, "placement.money"
+	}
+	return true, ""
+}
+
+// hoveredCell returns the grid cell under the mouse or gamepad cursor, or false when the
+// mouse is outside the window or the map, or over a UI widget
+func (g *Game) hoveredCell() (float64, float64, bool) {
+	if g.usingGamepad {
+		return g.gamepadCursor.X, g.gamepadCursor.Y, true
+	}
+	x, y := ebiten.CursorPosition()
+	if x < 0 || y < 0 || x >= g.screenWidth || y >= g.screenHeight {
+		return 0, 0, false
+	}
+	if g.ui.Hovered != nil {
+		return 0, 0, false
+	}
+
+	worldX, worldY := g.camera.ScreenToWorld(float64(x), float64(y))
+	cellSize := float64(g.config.GridSize)
+	gridX, gridY := math.Floor(worldX/cellSize), math.Floor(worldY/cellSize)
+	mapWidth, mapHeight := g.config.MapSize()
+	if gridX < 0 || gridY < 0 || gridX >= float64(mapWidth) || gridY >= float64(mapHeight) {
+		return 0, 0, false
+	}
+	return gridX, gridY, true
+}
+
+// submitPlacementGhost previews the selected tower at the hovered cell, with the reason a
+// blocked cell cannot be built on below it at screen size
+func (g *Game) submitPlacementGhost(queue *RenderQueue) {
+	gridX, gridY, ok := g.hoveredCell()
+	if !ok || g.isTowerAt(gridX, gridY) {
+		// Hovering an existing tower selects it on click, so no ghost
+		return
+	}
+
+	cellSize := float64(g.config.GridSize)
+	center := Point{gridX*cellSize + cellSize/2, gridY*cellSize + cellSize/2}
+	_, _, rangeVal, _ := g.config.GetTowerStats(g.selectedTowerType)
+	valid, reason := g.placementCheck(gridX, gridY)
+
+	g.graphics.SubmitPlacementGhost(queue, g.selectedTowerType, center, rangeVal, g.config.GridSize, valid)
+	if !valid {
+		reason = g.loc.T(reason)
+		queue.Submit(LayerUI, center.Y, func(screen *ebiten.Image) {
+			w, _ := g.ui.Fonts.Measure(TextTooltip, reason)
+			x, y := g.camera.WorldToScreen(center.X, center.Y+cellSize/2)
+			g.ui.Fonts.Draw(screen, TextTooltip, reason, int(x)-w/2, int(y)+2, g.theme.Text)
+		})
+	}
+}
+
+// pointOnSegment reports whether a cell lies on the path segment between two waypoints
+func pointOnSegment(gridX, gridY float64, a, b Point) bool {
+	dx := b.X - a.X
+	dy := b.Y - a.Y
+	lengthSq := dx*dx + dy*dy
+	t := 0.0
+	if lengthSq > 0 {
+		t = math.Max(0, math.Min(1, ((gridX-a.X)*dx+(gridY-a.Y)*dy)/lengthSq))
+	}
+	px := a.X + t*dx - gridX
+	py := a.Y + t*dy - gridY
+	return math.Sqrt(px*px+py*py) < 0.5
+}
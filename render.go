@@ -0,0 +1,73 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// RenderLayer orders what is drawn each frame; lower layers are drawn first
+type RenderLayer int
+
+const (
+	LayerGround       RenderLayer = iota // Terrain and path
+	LayerGroundDecals                    // Range circles, aura areas and placement highlights
+	LayerShadows                         // Shadows, below every unit
+	LayerUnits                           // Towers and enemies, sorted by y
+	LayerProjectiles                     // Projectiles in flight
+	LayerEffects                         // Particles and muzzle flashes
+	LayerOverlays                        // Health bars, selection rings, cursors and the placement preview
+	LayerUI                              // Screen-space labels over the world, drawn after the camera
+)
+
+// lastWorldLayer is the top layer drawn into the world image; later layers use screen pixels
+const lastWorldLayer = LayerOverlays
+
+// drawCommand is one piece of the frame submitted to a RenderQueue
+type drawCommand struct {
+	layer RenderLayer
+	y     float64 // Position on the map; within a layer, things further down are drawn on top
+	draw  func(target *ebiten.Image)
+}
+
+// RenderQueue collects a frame's draw commands so entities can submit them in any order and
+// still be drawn layer by layer, back to front
+type RenderQueue struct {
+	commands []drawCommand
+	sorted   bool
+}
+
+// Reset drops the previous frame's commands, keeping the buffer
+func (q *RenderQueue) Reset() {
+	q.commands = q.commands[:0]
+	q.sorted = true
+}
+
+// Submit adds a command to a layer; y sorts commands within the layer
+func (q *RenderQueue) Submit(layer RenderLayer, y float64, draw func(target *ebiten.Image)) {
+	q.commands = append(q.commands, drawCommand{layer: layer, y: y, draw: draw})
+	q.sorted = false
+}
+
+// Draw runs the commands of layers first to last onto target, in layer and y order; commands
+// with equal keys keep the order they were submitted in
+func (q *RenderQueue) Draw(target *ebiten.Image, first, last RenderLayer) {
+	if !q.sorted {
+		sort.SliceStable(q.commands, func(i, j int) bool {
+			a, b := q.commands[i], q.commands[j]
+			if a.layer != b.layer {
+				return a.layer < b.layer
+			}
+			return a.y < b.y
+		})
+		q.sorted = true
+	}
+
+	start := sort.Search(len(q.commands), func(i int) bool { return q.commands[i].layer >= first })
+	for _, cmd := range q.commands[start:] {
+		if cmd.layer > last {
+			break
+		}
+		cmd.draw(target)
+	}
+}
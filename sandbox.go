@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	sandboxHealthStep = 25   // Health change per sandbox health key press
+	sandboxSpeedStep  = 0.25 // Enemy speed change per sandbox speed key press
+)
+
+// setupSandbox resets the field for sandbox mode; no waves spawn on their own
+func (gmm *GameModeManager) setupSandbox(game *Game) {
+	game.enemies = []*Enemy{}
+	game.projectiles = []*Projectile{}
+	game.money = game.config.StartingMoney
+	game.lives = game.config.StartingLives
+	game.wave = 1
+	game.enemiesSpawned = 0
+	game.enemiesPerWave = 0
+	game.spawnTimer = 0
+	game.gameOver = false
+	game.gameSpeed = 1.0
+	game.tickAccumulator = 0
+}
+
+// updateSandboxMode handles the sandbox spawning and speed hotkeys
+func (gmm *GameModeManager) updateSandboxMode(game *Game) error {
+	// Spawn an enemy with the current sandbox stats
+	if game.input.JustPressed(ActionSandboxSpawn) {
+		game.spawnEnemyWith(gmm.SandboxHealth, gmm.SandboxSpeed)
+		if game.config.DebugMode {
+			fmt.Printf("Sandbox spawn: health=%d speed=%.2f\n", gmm.SandboxHealth, gmm.SandboxSpeed)
+		}
+	}
+
+	// Adjust spawn health (hold the large step key for larger steps)
+	healthStep := sandboxHealthStep
+	if game.input.Held(ActionSandboxLargeStep) {
+		healthStep *= 10
+	}
+	if game.input.JustPressed(ActionSandboxHealthUp) {
+		gmm.SandboxHealth += healthStep
+	}
+	if game.input.JustPressed(ActionSandboxHealthDown) {
+		gmm.SandboxHealth = maxInt(1, gmm.SandboxHealth-healthStep)
+	}
+
+	// Adjust spawn speed
+	if game.input.JustPressed(ActionSandboxSpeedUp) {
+		gmm.SandboxSpeed += sandboxSpeedStep
+	}
+	if game.input.JustPressed(ActionSandboxSpeedDown) {
+		gmm.SandboxSpeed = max(sandboxSpeedStep, gmm.SandboxSpeed-sandboxSpeedStep)
+	}
+
+	// Clear the field of enemies and projectiles
+	if game.input.JustPressed(ActionSandboxClear) {
+		game.enemies = []*Enemy{}
+		game.projectiles = []*Projectile{}
+	}
+
+	return nil
+}
+
+// sandboxInfoText describes the sandbox spawn settings and controls
+func (gmm *GameModeManager) sandboxInfoText(game *Game) string {
+	input := game.input
+	return game.loc.T("mode.sandbox", speedLabel(game.gameSpeed), gmm.SandboxHealth, gmm.SandboxSpeed,
+		input.Label(ActionSandboxSpawn), input.Label(ActionSandboxHealthDown), input.Label(ActionSandboxHealthUp),
+		input.Label(ActionSandboxSpeedDown), input.Label(ActionSandboxSpeedUp), input.Label(ActionSandboxClear))
+}
+
+// submitSandboxDPS renders live DPS below each tower on the UI layer, so the text stays at
+// screen size at any zoom
+func (g *Game) submitSandboxDPS(queue *RenderQueue) {
+	queue.Submit(LayerUI, 0, func(screen *ebiten.Image) {
+		for _, tower := range g.towers {
+			dpsText := g.loc.T("sandbox.dps", tower.DPS)
+			w, _ := g.ui.Fonts.Measure(TextTooltip, dpsText)
+			x, y := g.camera.WorldToScreen(tower.Position.X, tower.Position.Y+20)
+			g.ui.Fonts.Draw(screen, TextTooltip, dpsText, int(x)-w/2, int(y), g.theme.Text)
+		}
+	})
+}
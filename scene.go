@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// goldenTolerance is how far a color channel may drift from a golden image before the pixel
+// counts as different, so small GPU rounding differences between machines pass
+const goldenTolerance = 2
+
+// Scene is a game state to render offscreen, read from a JSON file. Positions are in map
+// cells: a tower stands on a whole cell, while enemies and projectiles may sit anywhere,
+// e.g. 3.5 is the middle of cell 3.
+type Scene struct {
+	Theme       string            `json:"theme"`     // Theme name or file; "" uses the config's theme
+	MapWidth    int               `json:"map_width"` // Map size in cells; 0 uses the config's map size
+	MapHeight   int               `json:"map_height"`
+	Path        []Point           `json:"path"` // Path waypoints in cells; empty uses the default path
+	Towers      []SceneTower      `json:"towers"`
+	Enemies     []SceneEnemy      `json:"enemies"`
+	Projectiles []SceneProjectile `json:"projectiles"`
+	Selected    *int              `json:"selected"` // Index of the selected tower, whose auras are shown
+}
+
+// SceneTower is a tower in a scene
+type SceneTower struct {
+	Type   int      `json:"type"`
+	X      int      `json:"x"`
+	Y      int      `json:"y"`
+	Level  int      `json:"level"`  // Upgrade level shown by the pips; 0 means 1
+	Aim    *float64 `json:"aim"`    // Barrel direction in degrees clockwise from pointing right; unset points up
+	Firing bool     `json:"firing"` // Draw the muzzle flash and recoil of a shot just fired
+}
+
+// SceneEnemy is an enemy in a scene
+type SceneEnemy struct {
+	X      float64  `json:"x"`
+	Y      float64  `json:"y"`
+	Health *float64 `json:"health"` // Fraction of health left, from 0 to 1; unset is full health
+	Frame  int      `json:"frame"`  // Animation frame
+}
+
+// SceneProjectile is a projectile in flight in a scene
+type SceneProjectile struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Damage int     `json:"damage"` // Decides the projectile's look, as the firing tower's damage does
+}
+
+// LoadScene reads a scene file
+func LoadScene(path string) (*Scene, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var scene Scene
+	if err := json.Unmarshal(data, &scene); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	for i, t := range scene.Towers {
+		if t.Type < TowerBasic || t.Type > towerTypeCount {
+			return nil, fmt.Errorf("%s: tower %d has unknown type %d", path, i, t.Type)
+		}
+	}
+	if scene.Selected != nil && (*scene.Selected < 0 || *scene.Selected >= len(scene.Towers)) {
+		return nil, fmt.Errorf("%s: selected tower %d does not exist", path, *scene.Selected)
+	}
+	return &scene, nil
+}
+
+// game builds a game holding only what the scene shows, drawn by gm. It sits on the menu, so
+// no placement preview or cursor is drawn.
+func (s *Scene) game(gm *GraphicsManager, config *GameConfig) *Game {
+	sceneConfig := *config
+	if s.MapWidth > 0 {
+		sceneConfig.MapWidth = s.MapWidth
+	}
+	if s.MapHeight > 0 {
+		sceneConfig.MapHeight = s.MapHeight
+	}
+	mapWidth, mapHeight := sceneConfig.MapSize()
+
+	g := &Game{
+		config:      &sceneConfig,
+		graphics:    gm,
+		modeManager: NewGameModeManager(),
+		path:        s.Path,
+		theme:       gm.Theme,
+	}
+	if len(g.path) == 0 {
+		g.path = defaultPath(mapWidth, mapHeight)
+	}
+
+	cellSize := float64(sceneConfig.GridSize)
+	for _, t := range s.Towers {
+		cost, damage, rangeVal, fireRate := sceneConfig.GetTowerStats(t.Type)
+		tower := &Tower{
+			Position:     Point{(float64(t.X) + 0.5) * cellSize, (float64(t.Y) + 0.5) * cellSize},
+			Range:        rangeVal,
+			Damage:       damage,
+			FireRate:     fireRate,
+			LastFire:     fireRate,
+			Cost:         cost,
+			Type:         t.Type,
+			BaseRange:    rangeVal,
+			BaseDamage:   damage,
+			BaseFireRate: fireRate,
+			Auras:        sceneConfig.GetTowerAuras(t.Type),
+			Level:        t.Level,
+			AimAngle:     -math.Pi / 2,
+		}
+		if tower.Level < 1 {
+			tower.Level = 1
+		}
+		if t.Aim != nil {
+			tower.AimAngle = *t.Aim * math.Pi / 180
+		}
+		if t.Firing {
+			tower.LastFire = 0
+			tower.Recoil = 1
+			tower.Anim.Play(gm.TowerSprites[t.Type], fireAnimation)
+		}
+		g.towers = append(g.towers, tower)
+	}
+	g.recalculateAuras()
+	if s.Selected != nil {
+		g.selectedTower = g.towers[*s.Selected]
+	}
+
+	for _, e := range s.Enemies {
+		enemy := &Enemy{
+			Position:  Point{e.X * cellSize, e.Y * cellSize},
+			Health:    100,
+			MaxHealth: 100,
+			Alive:     true,
+			Anim:      AnimState{Frame: e.Frame},
+		}
+		if e.Health != nil {
+			enemy.Health = int(math.Round(math.Max(0, math.Min(1, *e.Health)) * 100))
+		}
+		g.enemies = append(g.enemies, enemy)
+	}
+
+	for _, p := range s.Projectiles {
+		g.projectiles = append(g.projectiles, &Projectile{
+			Position: Point{p.X * cellSize, p.Y * cellSize},
+			Damage:   p.Damage,
+			Active:   true,
+		})
+	}
+	return g
+}
+
+// graphics creates a graphics manager with the scene's theme, or else the config's, and the
+// sprites of the config's assets directory
+func (s *Scene) graphics(config *GameConfig) (*GraphicsManager, error) {
+	themeName := config.Theme
+	if s.Theme != "" {
+		themeName = s.Theme
+	}
+	theme, err := LoadTheme(themeName)
+	if err != nil {
+		return nil, err
+	}
+	gm := NewGraphicsManager(theme, 0)
+	if err := gm.LoadSprites(config.AssetsDir); err != nil {
+		return nil, err
+	}
+	return gm, nil
+}
+
+// RenderScene draws a scene's map with everything on it into a new image, the way the game
+// draws its world image before the camera. No particles are drawn, so the result only depends
+// on the scene, the config and the graphics manager's theme and sprites.
+func RenderScene(gm *GraphicsManager, scene *Scene, config *GameConfig) *ebiten.Image {
+	g := scene.game(gm, config)
+	mapWidth, mapHeight := g.config.MapSize()
+	img := ebiten.NewImage(mapWidth*g.config.GridSize, mapHeight*g.config.GridSize)
+	g.drawWorld(img)
+	return img
+}
+
+// diffPixels counts the pixels of two images that differ by more than tolerance in any
+// channel; images of different sizes differ everywhere
+func diffPixels(a, b image.Image, tolerance int) int {
+	boundsA, boundsB := a.Bounds(), b.Bounds()
+	if boundsA.Dx() != boundsB.Dx() || boundsA.Dy() != boundsB.Dy() {
+		return maxInt(boundsA.Dx()*boundsA.Dy(), boundsB.Dx()*boundsB.Dy())
+	}
+
+	differing := 0
+	for y := 0; y < boundsA.Dy(); y++ {
+		for x := 0; x < boundsA.Dx(); x++ {
+			r1, g1, b1, a1 := a.At(boundsA.Min.X+x, boundsA.Min.Y+y).RGBA()
+			r2, g2, b2, a2 := b.At(boundsB.Min.X+x, boundsB.Min.Y+y).RGBA()
+			for _, d := range []int{int(r1>>8) - int(r2>>8), int(g1>>8) - int(g2>>8), int(b1>>8) - int(b2>>8), int(a1>>8) - int(a2>>8)} {
+				if d > tolerance || d < -tolerance {
+					differing++
+					break
+				}
+			}
+		}
+	}
+	return differing
+}
+
+// readPNG decodes a PNG file
+func readPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return png.Decode(file)
+}
+
+// RunRender renders a scene file with the given config and saves it as a PNG, or with check
+// set, compares it against that PNG as a golden image and fails when they differ
+func RunRender(scenePath, pngPath string, config *GameConfig, check bool) error {
+	scene, err := LoadScene(scenePath)
+	if err != nil {
+		return err
+	}
+	gm, err := scene.graphics(config)
+	if err != nil {
+		return err
+	}
+
+	return runInGameLoop("Render", func() error {
+		img := RenderScene(gm, scene, config)
+		if !check {
+			return SavePNG(img, pngPath)
+		}
+
+		golden, err := readPNG(pngPath)
+		if err != nil {
+			return err
+		}
+		if n := diffPixels(imageToRGBA(img), golden, goldenTolerance); n > 0 {
+			return fmt.Errorf("%s: %d pixels differ from %s", scenePath, n, pngPath)
+		}
+		return nil
+	})
+}
+
+// runInGameLoop calls fn from the first Update of a small window that closes right after.
+// Ebiten only draws to offscreen images and reads their pixels once its game loop runs, so
+// offscreen work from the command line goes through here.
+func runInGameLoop(title string, fn func() error) error {
+	ebiten.SetWindowSize(320, 240)
+	ebiten.SetWindowTitle(title)
+	runner := &loopRunner{fn: fn}
+	if err := ebiten.RunGame(runner); err != nil {
+		return err
+	}
+	return runner.err
+}
+
+// loopRunner is the game that runs a function on its first update
+type loopRunner struct {
+	fn  func() error
+	err error
+}
+
+func (r *loopRunner) Update() error {
+	r.err = r.fn()
+	return ebiten.Termination
+}
+
+func (r *loopRunner) Draw(screen *ebiten.Image) {}
+
+func (r *loopRunner) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
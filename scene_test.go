@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "save the renders of testdata/scenes as the golden images in testdata/golden")
+
+// TestSceneGoldens renders every scene in testdata/scenes with the default config and built-in
+// sprites, and compares it with the golden image of the same name in testdata/golden
+func TestSceneGoldens(t *testing.T) {
+	scenes, err := filepath.Glob(filepath.Join("testdata", "scenes", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scenes) == 0 {
+		t.Fatal("no scenes in testdata/scenes")
+	}
+
+	config := DefaultConfig()
+	config.AssetsDir = ""
+	for _, scenePath := range scenes {
+		scenePath := scenePath
+		name := strings.TrimSuffix(filepath.Base(scenePath), ".json")
+		t.Run(name, func(t *testing.T) {
+			scene, err := LoadScene(scenePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gm, err := scene.graphics(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			img := RenderScene(gm, scene, config)
+
+			goldenPath := filepath.Join("testdata", "golden", name+".png")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(goldenPath), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := SavePNG(img, goldenPath); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			golden, err := readPNG(goldenPath)
+			if err != nil {
+				t.Fatalf("%v; run make goldens to save it", err)
+			}
+			if n := diffPixels(imageToRGBA(img), golden, goldenTolerance); n > 0 {
+				t.Errorf("%d pixels differ from %s", n, goldenPath)
+			}
+		})
+	}
+}
+
+func TestDiffPixels(t *testing.T) {
+	// base is a 4x4 image with a gray pixel at the origin
+	base := func() *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+		img.Set(0, 0, color.RGBA{100, 100, 100, 255})
+		return img
+	}
+
+	nudged := base()
+	nudged.Set(0, 0, color.RGBA{102, 98, 100, 255})
+	nudged.Set(3, 3, color.RGBA{0, 0, 0, 2})
+	changed := base()
+	changed.Set(0, 0, color.RGBA{103, 100, 100, 255})
+	changed.Set(1, 2, color.RGBA{0, 0, 0, 255})
+	offset := image.NewRGBA(image.Rect(5, 5, 9, 9))
+	offset.Set(5, 5, color.RGBA{100, 100, 100, 255})
+
+	tests := []struct {
+		name      string
+		img       image.Image
+		tolerance int
+		want      int
+	}{
+		{"same", base(), 0, 0},
+		{"within the tolerance", nudged, 2, 0},
+		{"nudged without a tolerance", nudged, 0, 2},
+		{"beyond the tolerance", changed, 2, 2},
+		{"same pixels at another origin", offset, 0, 0},
+		{"different size", image.NewRGBA(image.Rect(0, 0, 5, 3)), 255, 16},
+	}
+	for _, test := range tests {
+		if got := diffPixels(base(), test.img, test.tolerance); got != test.want {
+			t.Errorf("%s: got %d differing pixels, want %d", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Scale modes for the scale_mode option
+const (
+	scaleModeExpand    = "expand"    // The screen grows with the window and shows more of the map
+	scaleModeLetterbox = "letterbox" // The window_width x window_height screen is scaled to fit, with bars
+)
+
+// Minimum window size; smaller windows don't leave room for the HUD
+const (
+	minWindowWidth  = 640
+	minWindowHeight = 480
+)
+
+// Layout sizes the screen for the window. The screen is always rendered at the window's real
+// pixel size, so text and sprites stay sharp; pixelScale is how many screen pixels stand for
+// one pixel of the window_width x window_height layout.
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if outsideWidth <= 0 || outsideHeight <= 0 {
+		// Minimized; keep the last size
+		return g.screenWidth, g.screenHeight
+	}
+
+	deviceScale := 1.0
+	if g.config.HiDPI {
+		deviceScale = ebiten.DeviceScaleFactor()
+	}
+
+	width, height := float64(outsideWidth), float64(outsideHeight)
+	scale := deviceScale
+	if g.config.ScaleMode == scaleModeLetterbox {
+		// Ebiten centers the screen and fills the rest of the window with bars
+		logicalWidth, logicalHeight := float64(g.config.WindowWidth), float64(g.config.WindowHeight)
+		scale *= math.Min(width/logicalWidth, height/logicalHeight)
+		width, height = logicalWidth, logicalHeight
+	}
+
+	g.setScreenSize(int(math.Round(width*scale)), int(math.Round(height*scale)), scale)
+	return g.screenWidth, g.screenHeight
+}
+
+// setScreenSize applies a new screen size and pixel scale to the fonts and the camera
+func (g *Game) setScreenSize(width, height int, pixelScale float64) {
+	if width == g.screenWidth && height == g.screenHeight && pixelScale == g.pixelScale {
+		return
+	}
+	g.screenWidth, g.screenHeight = width, height
+	g.pixelScale = pixelScale
+	g.ui.Fonts.SetScale(g.config.UIScale * pixelScale)
+	g.camera.SetView(width, height, pixelScale)
+
+	if g.config.DebugMode {
+		fmt.Printf("Screen resized to %dx%d (pixel scale %.2f)\n", width, height, pixelScale)
+	}
+}
+
+// anchor returns a PositionFunc that pins a panel to the screen. ax and ay pick the point of
+// the screen and of the panel that line up (0 = left/top, 0.5 = center, 1 = right/bottom);
+// the margins, in layout pixels, push the panel in from the edge it is pinned to.
+func anchor(g *Game, ax, ay float64, marginX, marginY int) func(w, h int) (int, int) {
+	return func(w, h int) (int, int) {
+		x := ax*float64(g.screenWidth-w) + (1-2*ax)*float64(g.ui.Px(marginX))
+		y := ay*float64(g.screenHeight-h) + (1-2*ay)*float64(g.ui.Px(marginY))
+		return int(x), int(y)
+	}
+}
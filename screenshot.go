@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// imageToRGBA copies the pixels of an Ebiten image into memory. Both keep alpha premultiplied,
+// so the bytes are copied as they are.
+func imageToRGBA(img *ebiten.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	img.ReadPixels(rgba.Pix)
+	return rgba
+}
+
+// SavePNG encodes the pixels of an image as a PNG file. Ebiten only reads pixels back once the
+// game loop runs, so call it from Update or Draw.
+func SavePNG(img *ebiten.Image, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, imageToRGBA(img)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// SaveScreenshot saves the screen into dir, named after the time it was taken, and returns the
+// file's path
+func SaveScreenshot(screen *ebiten.Image, dir string, now time.Time) (string, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", err
+		}
+	}
+	name := fmt.Sprintf("screenshot-%s-%03d.png", now.Format("20060102-150405"), now.Nanosecond()/int(time.Millisecond))
+	path := filepath.Join(dir, name)
+	return path, SavePNG(screen, path)
+}
+
+// takeScreenshot saves the finished frame when the screenshot key was pressed
+func (g *Game) takeScreenshot(screen *ebiten.Image) {
+	if !g.screenshotPending {
+		return
+	}
+	g.screenshotPending = false
+
+	path, err := SaveScreenshot(screen, g.config.ScreenshotDir, time.Now())
+	if err != nil {
+		log.Printf("Error saving screenshot: %v", err)
+		return
+	}
+	log.Printf("Saved screenshot %s", path)
+}
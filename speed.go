@@ -0,0 +1,50 @@
+package main
+
+import "fmt"
+
+// gameSpeeds lists the selectable simulation speed multipliers
+var gameSpeeds = []float64{0.5, 1.0, 2.0, 4.0}
+
+// handleSpeedInput steps the game speed up and down with the speed keys (only on key press, not hold)
+func (g *Game) handleSpeedInput() {
+	if g.input.JustPressed(ActionSpeedUp) {
+		g.gameSpeed = nextGameSpeed(g.gameSpeed, 1)
+	}
+	if g.input.JustPressed(ActionSpeedDown) {
+		g.gameSpeed = nextGameSpeed(g.gameSpeed, -1)
+	}
+}
+
+// handleFrameStep advances the simulation by exactly one tick when the step key is pressed while paused
+func (g *Game) handleFrameStep() {
+	if g.input.JustPressed(ActionFrameStep) && !g.gameOver {
+		g.updateSimulation()
+		if g.config.DebugMode {
+			fmt.Printf("Frame step: wave time %.2fs, enemies %d\n", g.waveStartTime, len(g.enemies))
+		}
+	}
+}
+
+// nextGameSpeed steps through gameSpeeds in the given direction
+func nextGameSpeed(current float64, direction int) float64 {
+	index := 0
+	for i, speed := range gameSpeeds {
+		if speed <= current {
+			index = i
+		}
+	}
+
+	index += direction
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(gameSpeeds) {
+		index = len(gameSpeeds) - 1
+	}
+	return gameSpeeds[index]
+}
+
+// speedLabel formats the game speed for the HUD
+func speedLabel(speed float64) string {
+	return fmt.Sprintf("%gx", speed)
+}
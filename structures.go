@@ -0,0 +1,12 @@
+package main
+
+// bankIncome returns the money all banks pay out at a wave transition
+func (g *Game) bankIncome() int {
+	income := 0
+	for _, tower := range g.towers {
+		if tower.Type == TowerBank {
+			income += g.config.BankIncome
+		}
+	}
+	return income
+}
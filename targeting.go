@@ -0,0 +1,86 @@
+package main
+
+import "math"
+
+// TargetingMode decides which enemy in range a tower shoots at
+type TargetingMode int
+
+const (
+	TargetNearest TargetingMode = iota
+	TargetFirst
+	TargetLast
+	TargetStrongest
+	TargetWeakest
+)
+
+// LocaleKey returns the localization key of the targeting mode's display name
+func (m TargetingMode) LocaleKey() string {
+	switch m {
+	case TargetFirst:
+		return "targeting.first"
+	case TargetLast:
+		return "targeting.last"
+	case TargetStrongest:
+		return "targeting.strongest"
+	case TargetWeakest:
+		return "targeting.weakest"
+	default:
+		return "targeting.nearest"
+	}
+}
+
+// Next cycles to the following targeting mode
+func (m TargetingMode) Next() TargetingMode {
+	return (m + 1) % (TargetWeakest + 1)
+}
+
+// findTarget picks an enemy in range according to the tower's targeting mode
+func (g *Game) findTarget(tower *Tower) *Enemy {
+	if tower.Targeting == TargetNearest {
+		return g.findNearestEnemy(tower)
+	}
+
+	var best *Enemy
+	bestScore := math.Inf(-1)
+
+	for _, enemy := range g.enemies {
+		if !enemy.Alive {
+			continue
+		}
+
+		dx := enemy.Position.X - tower.Position.X
+		dy := enemy.Position.Y - tower.Position.Y
+		if math.Sqrt(dx*dx+dy*dy) > tower.Range {
+			continue
+		}
+
+		var score float64
+		switch tower.Targeting {
+		case TargetFirst:
+			score = g.pathProgress(enemy)
+		case TargetLast:
+			score = -g.pathProgress(enemy)
+		case TargetStrongest:
+			score = float64(enemy.Health)
+		case TargetWeakest:
+			score = -float64(enemy.Health)
+		}
+
+		if score > bestScore {
+			best = enemy
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+// pathProgress measures how far along the path an enemy has travelled
+func (g *Game) pathProgress(enemy *Enemy) float64 {
+	dx := enemy.Target.X - enemy.Position.X
+	dy := enemy.Target.Y - enemy.Position.Y
+	remaining := math.Sqrt(dx*dx + dy*dy)
+
+	// Each waypoint reached outweighs any distance within a single segment
+	return float64(enemy.PathIndex)*100000 - remaining
+}
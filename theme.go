@@ -0,0 +1,517 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultTheme is the built-in palette every theme file falls back to for roles it leaves out
+const defaultTheme = "default"
+
+//go:embed themes/*.json
+var themeFiles embed.FS
+
+// Theme is a named color palette. Each color has a role ("terrain.grass", "ui.button", ...)
+// that theme files set as "#rrggbb" or "#rrggbbaa".
+type Theme struct {
+	Name string
+
+	// Map, terrain and markers on it
+	Background       color.RGBA
+	Grass            color.RGBA
+	GrassDetail      color.RGBA
+	Path             color.RGBA
+	PathStone        color.RGBA
+	PathLine         color.RGBA
+	PathBorder       color.RGBA
+	PathPebble       color.RGBA
+	PlacementValid   color.RGBA
+	PlacementBlocked color.RGBA
+	GamepadCursor    color.RGBA
+	Selection        color.RGBA
+	RangeRing        color.RGBA
+	LevelPip         color.RGBA
+	MuzzleFlash      color.RGBA
+	MuzzleFlashCore  color.RGBA
+
+	// Towers
+	Foundation        color.RGBA
+	FoundationEdge    color.RGBA
+	BasicBody         color.RGBA
+	BasicShine        color.RGBA
+	BasicBarrel       color.RGBA
+	BasicMuzzle       color.RGBA
+	HeavyBody         color.RGBA
+	HeavyArmor        color.RGBA
+	HeavyBarrel       color.RGBA
+	HeavyMuzzle       color.RGBA
+	HeavyCore         color.RGBA
+	SniperBase        color.RGBA
+	SniperPlatform    color.RGBA
+	SniperBarrel      color.RGBA
+	SniperMuzzle      color.RGBA
+	SniperScope       color.RGBA
+	SniperLens        color.RGBA
+	SniperCore        color.RGBA
+	LaserBase         color.RGBA
+	LaserCore         color.RGBA
+	LaserEmitter      color.RGBA
+	LaserBeam         color.RGBA
+	LaserCenter       color.RGBA
+	SplashBase        color.RGBA
+	SplashRingOuter   color.RGBA
+	SplashRingInner   color.RGBA
+	SplashTube        color.RGBA
+	SplashCharge      color.RGBA
+	SplashShell       color.RGBA
+	SplashOpening     color.RGBA
+	SlowBase          color.RGBA
+	SlowCrystal       color.RGBA
+	SlowWave          color.RGBA
+	SlowCore          color.RGBA
+	SlowFrost         color.RGBA
+	BankVault         color.RGBA
+	BankVaultEdge     color.RGBA
+	BankColumn        color.RGBA
+	BankCoin          color.RGBA
+	BankCoinEdge      color.RGBA
+	BankGlint         color.RGBA
+	RadarPlatform     color.RGBA
+	RadarPlatformEdge color.RGBA
+	RadarBeam         color.RGBA
+	RadarDish         color.RGBA
+	RadarMast         color.RGBA
+	ArmoryWall        color.RGBA
+	ArmoryWallEdge    color.RGBA
+	ArmoryForge       color.RGBA
+	ArmoryBlade       color.RGBA
+
+	// Auras
+	AuraRange      color.RGBA
+	AuraFireRate   color.RGBA
+	AuraDamage     color.RGBA
+	AuraCritChance color.RGBA
+
+	// Enemies; the body fades from Healthy to Damaged as health drops
+	EnemyShadow  color.RGBA
+	EnemyHealthy color.RGBA
+	EnemyDamaged color.RGBA
+	EnemyArmor   color.RGBA
+	EnemyEyes    color.RGBA
+
+	// Health bars
+	HealthBorder color.RGBA
+	HealthEmpty  color.RGBA
+	HealthHigh   color.RGBA
+	HealthMid    color.RGBA
+	HealthLow    color.RGBA
+	HealthShine  color.RGBA
+
+	// Projectiles and particles
+	BulletBasic      color.RGBA
+	BulletBasicGlow  color.RGBA
+	BulletSniper     color.RGBA
+	BulletSniperGlow color.RGBA
+	BulletSplash     color.RGBA
+	BulletSplashGlow color.RGBA
+	BulletLaser      color.RGBA
+	BulletLaserGlow  color.RGBA
+	EnemyTrail       color.RGBA
+	BulletTrail      color.RGBA
+	Explosion        color.RGBA
+
+	// UI
+	Text                color.RGBA
+	TextDisabled        color.RGBA
+	TextShadow          color.RGBA
+	Title               color.RGBA
+	Panel               color.RGBA
+	PanelBorder         color.RGBA
+	HUDBackground       color.RGBA
+	BannerBackground    color.RGBA
+	Tooltip             color.RGBA
+	TooltipBorder       color.RGBA
+	Button              color.RGBA
+	ButtonHover         color.RGBA
+	ButtonPressed       color.RGBA
+	ButtonDisabled      color.RGBA
+	ButtonBorder        color.RGBA
+	ButtonSelected      color.RGBA
+	ListSelected        color.RGBA
+	ListSelectedBorder  color.RGBA
+	OverlayLevelInfo    color.RGBA
+	OverlayGameOver     color.RGBA
+	OverlayVictory      color.RGBA
+	OverlayPaused       color.RGBA
+	OverlayPausedBorder color.RGBA
+}
+
+// roles maps the role names used in theme files to the theme's colors
+func (t *Theme) roles() map[string]*color.RGBA {
+	return map[string]*color.RGBA{
+		"background":           &t.Background,
+		"terrain.grass":        &t.Grass,
+		"terrain.grass_detail": &t.GrassDetail,
+		"terrain.path":         &t.Path,
+		"terrain.path_stone":   &t.PathStone,
+		"terrain.path_line":    &t.PathLine,
+		"terrain.path_border":  &t.PathBorder,
+		"terrain.path_pebble":  &t.PathPebble,
+		"placement.valid":      &t.PlacementValid,
+		"placement.blocked":    &t.PlacementBlocked,
+		"gamepad_cursor":       &t.GamepadCursor,
+		"tower.selection":      &t.Selection,
+		"tower.range":          &t.RangeRing,
+		"tower.level_pip":      &t.LevelPip,
+		"tower.muzzle_flash":   &t.MuzzleFlash,
+		"tower.muzzle_core":    &t.MuzzleFlashCore,
+
+		"tower.foundation":      &t.Foundation,
+		"tower.foundation_edge": &t.FoundationEdge,
+		"basic.body":            &t.BasicBody,
+		"basic.shine":           &t.BasicShine,
+		"basic.barrel":          &t.BasicBarrel,
+		"basic.muzzle":          &t.BasicMuzzle,
+		"heavy.body":            &t.HeavyBody,
+		"heavy.armor":           &t.HeavyArmor,
+		"heavy.barrel":          &t.HeavyBarrel,
+		"heavy.muzzle":          &t.HeavyMuzzle,
+		"heavy.core":            &t.HeavyCore,
+		"sniper.base":           &t.SniperBase,
+		"sniper.platform":       &t.SniperPlatform,
+		"sniper.barrel":         &t.SniperBarrel,
+		"sniper.muzzle":         &t.SniperMuzzle,
+		"sniper.scope":          &t.SniperScope,
+		"sniper.lens":           &t.SniperLens,
+		"sniper.core":           &t.SniperCore,
+		"laser.base":            &t.LaserBase,
+		"laser.core":            &t.LaserCore,
+		"laser.emitter":         &t.LaserEmitter,
+		"laser.beam":            &t.LaserBeam,
+		"laser.center":          &t.LaserCenter,
+		"splash.base":           &t.SplashBase,
+		"splash.ring_outer":     &t.SplashRingOuter,
+		"splash.ring_inner":     &t.SplashRingInner,
+		"splash.tube":           &t.SplashTube,
+		"splash.charge":         &t.SplashCharge,
+		"splash.shell":          &t.SplashShell,
+		"splash.opening":        &t.SplashOpening,
+		"slow.base":             &t.SlowBase,
+		"slow.crystal":          &t.SlowCrystal,
+		"slow.wave":             &t.SlowWave,
+		"slow.core":             &t.SlowCore,
+		"slow.frost":            &t.SlowFrost,
+		"bank.vault":            &t.BankVault,
+		"bank.vault_edge":       &t.BankVaultEdge,
+		"bank.column":           &t.BankColumn,
+		"bank.coin":             &t.BankCoin,
+		"bank.coin_edge":        &t.BankCoinEdge,
+		"bank.glint":            &t.BankGlint,
+		"radar.platform":        &t.RadarPlatform,
+		"radar.platform_edge":   &t.RadarPlatformEdge,
+		"radar.beam":            &t.RadarBeam,
+		"radar.dish":            &t.RadarDish,
+		"radar.mast":            &t.RadarMast,
+		"armory.wall":           &t.ArmoryWall,
+		"armory.wall_edge":      &t.ArmoryWallEdge,
+		"armory.forge":          &t.ArmoryForge,
+		"armory.blade":          &t.ArmoryBlade,
+
+		"aura.range":       &t.AuraRange,
+		"aura.fire_rate":   &t.AuraFireRate,
+		"aura.damage":      &t.AuraDamage,
+		"aura.crit_chance": &t.AuraCritChance,
+
+		"enemy.shadow":  &t.EnemyShadow,
+		"enemy.healthy": &t.EnemyHealthy,
+		"enemy.damaged": &t.EnemyDamaged,
+		"enemy.armor":   &t.EnemyArmor,
+		"enemy.eyes":    &t.EnemyEyes,
+
+		"health.border": &t.HealthBorder,
+		"health.empty":  &t.HealthEmpty,
+		"health.high":   &t.HealthHigh,
+		"health.mid":    &t.HealthMid,
+		"health.low":    &t.HealthLow,
+		"health.shine":  &t.HealthShine,
+
+		"projectile.basic":       &t.BulletBasic,
+		"projectile.basic_glow":  &t.BulletBasicGlow,
+		"projectile.sniper":      &t.BulletSniper,
+		"projectile.sniper_glow": &t.BulletSniperGlow,
+		"projectile.splash":      &t.BulletSplash,
+		"projectile.splash_glow": &t.BulletSplashGlow,
+		"projectile.laser":       &t.BulletLaser,
+		"projectile.laser_glow":  &t.BulletLaserGlow,
+		"particle.enemy_trail":   &t.EnemyTrail,
+		"particle.bullet_trail":  &t.BulletTrail,
+		"particle.explosion":     &t.Explosion,
+
+		"ui.text":                 &t.Text,
+		"ui.text_disabled":        &t.TextDisabled,
+		"ui.text_shadow":          &t.TextShadow,
+		"ui.title":                &t.Title,
+		"ui.panel":                &t.Panel,
+		"ui.panel_border":         &t.PanelBorder,
+		"ui.hud":                  &t.HUDBackground,
+		"ui.banner":               &t.BannerBackground,
+		"ui.tooltip":              &t.Tooltip,
+		"ui.tooltip_border":       &t.TooltipBorder,
+		"ui.button":               &t.Button,
+		"ui.button_hover":         &t.ButtonHover,
+		"ui.button_pressed":       &t.ButtonPressed,
+		"ui.button_disabled":      &t.ButtonDisabled,
+		"ui.button_border":        &t.ButtonBorder,
+		"ui.button_selected":      &t.ButtonSelected,
+		"ui.list_selected":        &t.ListSelected,
+		"ui.list_selected_border": &t.ListSelectedBorder,
+		"overlay.level_info":      &t.OverlayLevelInfo,
+		"overlay.game_over":       &t.OverlayGameOver,
+		"overlay.victory":         &t.OverlayVictory,
+		"overlay.paused":          &t.OverlayPaused,
+		"overlay.paused_border":   &t.OverlayPausedBorder,
+	}
+}
+
+// DefaultTheme returns the built-in palette
+func DefaultTheme() *Theme {
+	return &Theme{
+		Name: defaultTheme,
+
+		Background:       color.RGBA{20, 30, 40, 255},
+		Grass:            color.RGBA{34, 139, 34, 255},
+		GrassDetail:      color.RGBA{40, 150, 40, 255},
+		Path:             color.RGBA{101, 67, 33, 255},
+		PathStone:        color.RGBA{85, 55, 25, 255},
+		PathLine:         color.RGBA{139, 99, 61, 255},
+		PathBorder:       color.RGBA{101, 67, 33, 180},
+		PathPebble:       color.RGBA{80, 60, 40, 200},
+		PlacementValid:   color.RGBA{80, 255, 120, 255},
+		PlacementBlocked: color.RGBA{255, 70, 70, 255},
+		GamepadCursor:    color.RGBA{255, 255, 255, 220},
+		Selection:        color.RGBA{255, 255, 255, 220},
+		RangeRing:        color.RGBA{255, 255, 255, 20},
+		LevelPip:         color.RGBA{255, 215, 0, 255},
+		MuzzleFlash:      color.RGBA{255, 255, 200, 100},
+		MuzzleFlashCore:  color.RGBA{255, 255, 150, 150},
+
+		Foundation:        color.RGBA{80, 80, 80, 255},
+		FoundationEdge:    color.RGBA{60, 60, 60, 255},
+		BasicBody:         color.RGBA{128, 128, 128, 255},
+		BasicShine:        color.RGBA{180, 180, 180, 200},
+		BasicBarrel:       color.RGBA{64, 64, 64, 255},
+		BasicMuzzle:       color.RGBA{40, 40, 40, 255},
+		HeavyBody:         color.RGBA{96, 96, 96, 255},
+		HeavyArmor:        color.RGBA{120, 120, 120, 255},
+		HeavyBarrel:       color.RGBA{48, 48, 48, 255},
+		HeavyMuzzle:       color.RGBA{32, 32, 32, 255},
+		HeavyCore:         color.RGBA{255, 100, 100, 255},
+		SniperBase:        color.RGBA{70, 70, 70, 255},
+		SniperPlatform:    color.RGBA{90, 90, 90, 255},
+		SniperBarrel:      color.RGBA{50, 50, 50, 255},
+		SniperMuzzle:      color.RGBA{30, 30, 30, 255},
+		SniperScope:       color.RGBA{40, 40, 40, 255},
+		SniperLens:        color.RGBA{200, 200, 255, 200},
+		SniperCore:        color.RGBA{100, 50, 50, 255},
+		LaserBase:         color.RGBA{60, 80, 120, 255},
+		LaserCore:         color.RGBA{100, 150, 255, 255},
+		LaserEmitter:      color.RGBA{150, 200, 255, 255},
+		LaserBeam:         color.RGBA{100, 200, 255, 100},
+		LaserCenter:       color.RGBA{200, 220, 255, 255},
+		SplashBase:        color.RGBA{80, 60, 40, 255},
+		SplashRingOuter:   color.RGBA{60, 40, 20, 255},
+		SplashRingInner:   color.RGBA{100, 80, 60, 255},
+		SplashTube:        color.RGBA{40, 40, 40, 255},
+		SplashCharge:      color.RGBA{255, 150, 50, 255},
+		SplashShell:       color.RGBA{150, 100, 50, 255},
+		SplashOpening:     color.RGBA{20, 20, 20, 255},
+		SlowBase:          color.RGBA{150, 200, 255, 200},
+		SlowCrystal:       color.RGBA{200, 230, 255, 180},
+		SlowWave:          color.RGBA{100, 150, 255, 255},
+		SlowCore:          color.RGBA{180, 220, 255, 255},
+		SlowFrost:         color.RGBA{200, 230, 255, 150},
+		BankVault:         color.RGBA{200, 180, 120, 255},
+		BankVaultEdge:     color.RGBA{140, 120, 70, 255},
+		BankColumn:        color.RGBA{230, 215, 170, 255},
+		BankCoin:          color.RGBA{255, 215, 0, 255},
+		BankCoinEdge:      color.RGBA{200, 160, 0, 255},
+		BankGlint:         color.RGBA{255, 255, 220, 220},
+		RadarPlatform:     color.RGBA{70, 90, 80, 255},
+		RadarPlatformEdge: color.RGBA{120, 200, 150, 200},
+		RadarBeam:         color.RGBA{100, 255, 150, 180},
+		RadarDish:         color.RGBA{180, 200, 190, 255},
+		RadarMast:         color.RGBA{40, 60, 50, 255},
+		ArmoryWall:        color.RGBA{110, 70, 60, 255},
+		ArmoryWallEdge:    color.RGBA{70, 40, 30, 255},
+		ArmoryForge:       color.RGBA{255, 120, 40, 255},
+		ArmoryBlade:       color.RGBA{210, 210, 220, 255},
+
+		AuraRange:      color.RGBA{100, 255, 150, 255},
+		AuraFireRate:   color.RGBA{255, 220, 80, 255},
+		AuraDamage:     color.RGBA{255, 90, 70, 255},
+		AuraCritChance: color.RGBA{200, 120, 255, 255},
+
+		EnemyShadow:  color.RGBA{0, 0, 0, 100},
+		EnemyHealthy: color.RGBA{255, 255, 255, 255},
+		EnemyDamaged: color.RGBA{255, 0, 0, 255},
+		EnemyArmor:   color.RGBA{200, 200, 200, 200},
+		EnemyEyes:    color.RGBA{255, 255, 0, 255},
+
+		HealthBorder: color.RGBA{0, 0, 0, 200},
+		HealthEmpty:  color.RGBA{100, 0, 0, 255},
+		HealthHigh:   color.RGBA{0, 255, 0, 255},
+		HealthMid:    color.RGBA{255, 255, 0, 255},
+		HealthLow:    color.RGBA{255, 0, 0, 255},
+		HealthShine:  color.RGBA{255, 255, 255, 100},
+
+		BulletBasic:      color.RGBA{255, 255, 100, 255},
+		BulletBasicGlow:  color.RGBA{255, 200, 100, 150},
+		BulletSniper:     color.RGBA{255, 100, 100, 255},
+		BulletSniperGlow: color.RGBA{255, 150, 150, 100},
+		BulletSplash:     color.RGBA{255, 150, 50, 255},
+		BulletSplashGlow: color.RGBA{255, 200, 100, 200},
+		BulletLaser:      color.RGBA{100, 200, 255, 255},
+		BulletLaserGlow:  color.RGBA{150, 220, 255, 150},
+		EnemyTrail:       color.RGBA{139, 69, 19, 80},
+		BulletTrail:      color.RGBA{255, 200, 100, 150},
+		Explosion:        color.RGBA{255, 150, 50, 200},
+
+		Text:                color.RGBA{255, 255, 255, 255},
+		TextDisabled:        color.RGBA{160, 160, 160, 255},
+		TextShadow:          color.RGBA{0, 0, 0, 200},
+		Title:               color.RGBA{255, 215, 0, 255},
+		Panel:               color.RGBA{15, 20, 30, 210},
+		PanelBorder:         color.RGBA{100, 150, 200, 255},
+		HUDBackground:       color.RGBA{0, 0, 0, 110},
+		BannerBackground:    color.RGBA{0, 0, 0, 150},
+		Tooltip:             color.RGBA{10, 10, 20, 235},
+		TooltipBorder:       color.RGBA{200, 200, 120, 255},
+		Button:              color.RGBA{50, 100, 150, 230},
+		ButtonHover:         color.RGBA{70, 130, 190, 240},
+		ButtonPressed:       color.RGBA{30, 70, 110, 240},
+		ButtonDisabled:      color.RGBA{60, 60, 60, 230},
+		ButtonBorder:        color.RGBA{150, 190, 230, 255},
+		ButtonSelected:      color.RGBA{255, 215, 0, 255},
+		ListSelected:        color.RGBA{50, 100, 150, 150},
+		ListSelectedBorder:  color.RGBA{100, 150, 200, 255},
+		OverlayLevelInfo:    color.RGBA{0, 0, 0, 150},
+		OverlayGameOver:     color.RGBA{0, 0, 0, 200},
+		OverlayVictory:      color.RGBA{0, 100, 0, 200},
+		OverlayPaused:       color.RGBA{20, 40, 80, 40},
+		OverlayPausedBorder: color.RGBA{100, 150, 255, 200},
+	}
+}
+
+// AvailableThemes lists the built-in theme names
+func AvailableThemes() []string {
+	themes := []string{defaultTheme}
+	entries, err := themeFiles.ReadDir("themes")
+	if err != nil {
+		return themes
+	}
+	for _, entry := range entries {
+		themes = append(themes, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(themes)
+	return themes
+}
+
+// LoadTheme loads a built-in theme by name, or a theme file when name ends in .json. Roles the
+// file leaves out keep their default color; on error the default theme is returned with
+// whatever colors could be read.
+func LoadTheme(name string) (*Theme, error) {
+	theme := DefaultTheme()
+	if name == "" || name == defaultTheme {
+		return theme, nil
+	}
+
+	var data []byte
+	var err error
+	if strings.HasSuffix(name, ".json") {
+		data, err = os.ReadFile(name)
+	} else {
+		data, err = themeFiles.ReadFile("themes/" + name + ".json")
+		if err != nil {
+			err = fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(AvailableThemes(), ", "))
+		}
+	}
+	if err != nil {
+		return theme, err
+	}
+
+	values := make(map[string]string)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return theme, fmt.Errorf("parsing theme %s: %v", name, err)
+	}
+
+	var problems []string
+	roles := theme.roles()
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		target, ok := roles[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: unknown color role", key))
+			continue
+		}
+		clr, err := parseHexColor(values[key])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		*target = clr
+	}
+
+	theme.Name = strings.TrimSuffix(path.Base(name), ".json")
+	if len(problems) > 0 {
+		return theme, errors.New("invalid theme colors: " + strings.Join(problems, "; "))
+	}
+	return theme, nil
+}
+
+// parseHexColor parses "#rrggbb" or "#rrggbbaa"
+func parseHexColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 && len(hex) != 8 {
+		return color.RGBA{}, fmt.Errorf("%q is not #rrggbb or #rrggbbaa", s)
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("%q is not #rrggbb or #rrggbbaa", s)
+	}
+	if len(hex) == 6 {
+		value = value<<8 | 0xff
+	}
+	return color.RGBA{uint8(value >> 24), uint8(value >> 16), uint8(value >> 8), uint8(value)}, nil
+}
+
+// withAlpha returns a color with its alpha replaced
+func withAlpha(c color.RGBA, alpha uint8) color.RGBA {
+	return color.RGBA{c.R, c.G, c.B, alpha}
+}
+
+// fadeAlpha returns a color with its alpha multiplied by f (0-1)
+func fadeAlpha(c color.RGBA, f float64) color.RGBA {
+	return withAlpha(c, uint8(math.Max(0, math.Min(float64(c.A)*f, 255))))
+}
+
+// lerpColor blends from a to b; t is clamped to 0-1
+func lerpColor(a, b color.RGBA, t float64) color.RGBA {
+	t = math.Max(0, math.Min(t, 1))
+	mix := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), mix(a.A, b.A)}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tower info panel layout, anchored to the right edge below the pause banner
+const (
+	towerPanelWidth  = 220
+	towerPanelTop    = 84
+	towerPanelMargin = 10
+)
+
+// newTowerPanel builds the selected tower's live stats and action buttons
+func newTowerPanel(g *Game) *Panel {
+	return &Panel{
+		PositionFunc: anchor(g, 1, 0, towerPanelMargin, towerPanelTop),
+		Padding:      10,
+		Spacing:      6,
+		MinWidth:     towerPanelWidth,
+		Background:   g.theme.Panel,
+		Border:       g.theme.PanelBorder,
+		VisibleFunc:  func() bool { return g.selectedTower != nil },
+		Children: []Widget{
+			&Label{TextFunc: func() string { return g.towerPanelText(g.selectedTower) }, Style: TextHUD},
+			&Button{
+				LabelFunc: func() string {
+					if !g.canUpgrade(g.selectedTower) {
+						return g.loc.T("panel.max_level")
+					}
+					return g.loc.T("panel.upgrade", g.input.Label(ActionUpgrade), g.loc.Money(g.upgradeCost(g.selectedTower)))
+				},
+				EnabledFunc: func() bool {
+					tower := g.selectedTower
+					return g.canUpgrade(tower) && (g.money >= g.upgradeCost(tower) || g.hasInfiniteResources())
+				},
+				OnClick: func() { g.upgradeTower(g.selectedTower) },
+			},
+			&Button{
+				LabelFunc: func() string {
+					return g.loc.T("panel.target", g.input.Label(ActionTargeting), g.loc.T(g.selectedTower.Targeting.LocaleKey()))
+				},
+				EnabledFunc: func() bool { return !g.config.IsSupportStructure(g.selectedTower.Type) },
+				OnClick:     func() { g.selectedTower.Targeting = g.selectedTower.Targeting.Next() },
+			},
+			&Button{
+				LabelFunc: func() string {
+					return g.loc.T("panel.sell", g.input.Label(ActionSell), g.loc.Money(g.sellValue(g.selectedTower)))
+				},
+				OnClick: func() { g.sellTower(g.selectedTower) },
+			},
+		},
+	}
+}
+
+// towerPanelText builds the stat lines shown on the tower info panel
+func (g *Game) towerPanelText(tower *Tower) string {
+	loc := g.loc
+	var lines []string
+
+	name := strings.ToUpper(g.towerName(tower.Type))
+	if g.config.IsSupportStructure(tower.Type) {
+		lines = append(lines, name)
+	} else {
+		lines = append(lines, loc.T("panel.title", name, tower.Level, g.config.MaxTowerLevel))
+		lines = append(lines,
+			loc.T("panel.damage", tower.Damage, tower.DamageBonus*100),
+			loc.T("panel.range", tower.Range, tower.RangeBonus*100),
+			loc.T("panel.fire_rate", tower.FireRate, tower.FireRateBonus*100),
+			loc.T("panel.crit", tower.CritChance*100))
+	}
+
+	// Special values such as splash radius or slow effect
+	keys := make([]string, 0, len(tower.Special))
+	for key := range tower.Special {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, loc.T("panel.special."+key, tower.Special[key]))
+	}
+
+	if tower.Type == TowerBank {
+		lines = append(lines, loc.T("panel.income", loc.Money(g.config.BankIncome)))
+	}
+	for _, aura := range tower.Auras {
+		lines = append(lines, loc.T("tooltip.aura", g.describeAura(aura)))
+	}
+
+	if !g.config.IsSupportStructure(tower.Type) {
+		lines = append(lines,
+			loc.T("panel.kills", tower.Kills),
+			loc.T("panel.damage_dealt", loc.Number(tower.DamageDealt)),
+			loc.T("panel.dps", tower.DPS))
+	}
+
+	return strings.Join(lines, "\n")
+}
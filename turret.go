@@ -0,0 +1,76 @@
+package main
+
+import "math"
+
+// Seconds a barrel takes to slide back into place after a shot
+const recoilTime = 0.15
+
+// aimAt turns the tower's turret toward a point by at most its turn rate over dt seconds;
+// towers without a turret face their target immediately
+func (t *Tower) aimAt(target Point, dt float64) {
+	desired := math.Atan2(target.Y-t.Position.Y, target.X-t.Position.X)
+	if t.TurnRate <= 0 {
+		t.AimAngle = desired
+		return
+	}
+	diff := angleDiff(desired, t.AimAngle)
+	step := t.TurnRate * dt
+	if math.Abs(diff) <= step {
+		t.AimAngle = desired
+	} else {
+		t.AimAngle = normalizeAngle(t.AimAngle + math.Copysign(step, diff))
+	}
+}
+
+// isFacing reports whether the turret points at a point within tolerance radians
+func (t *Tower) isFacing(target Point, tolerance float64) bool {
+	if t.TurnRate <= 0 {
+		return true
+	}
+	desired := math.Atan2(target.Y-t.Position.Y, target.X-t.Position.X)
+	return math.Abs(angleDiff(desired, t.AimAngle)) <= tolerance
+}
+
+// canFire reports whether a tower may shoot its target; with require_facing set, turrets
+// have to finish turning first
+func (g *Game) canFire(tower *Tower, target *Enemy) bool {
+	if !g.config.RequireFacing {
+		return true
+	}
+	return tower.isFacing(target.Position, g.config.AimTolerance*math.Pi/180)
+}
+
+// angleDiff returns the signed shortest rotation from b to a, in (-Pi, Pi]
+func angleDiff(a, b float64) float64 {
+	return normalizeAngle(a - b)
+}
+
+// normalizeAngle wraps an angle into (-Pi, Pi]
+func normalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle+math.Pi, 2*math.Pi)
+	if angle <= 0 {
+		angle += 2 * math.Pi
+	}
+	return angle - math.Pi
+}
+
+// turretBarrelLength returns how far a tower type's barrel reaches from its center, or 0
+// when it has no turret
+func turretBarrelLength(towerType int) float32 {
+	switch towerType {
+	case TowerBasic:
+		return 20
+	case TowerHeavy:
+		return 18
+	case TowerSniper:
+		return 35
+	default:
+		return 0
+	}
+}
+
+// muzzlePosition returns the tip of the tower's barrel, or its center when it has no turret
+func (t *Tower) muzzlePosition() Point {
+	length := float64(turretBarrelLength(t.Type))
+	return Point{t.Position.X + math.Cos(t.AimAngle)*length, t.Position.Y + math.Sin(t.AimAngle)*length}
+}
@@ -0,0 +1,561 @@
+package main
+
+import (
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Rect is a screen rectangle used for widget layout and hit-testing
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Contains reports whether a screen point is inside the rectangle
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
+
+// Align controls horizontal text alignment inside a widget
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+)
+
+// LayoutDirection controls how a panel stacks its children
+type LayoutDirection int
+
+const (
+	LayoutVertical LayoutDirection = iota
+	LayoutHorizontal
+)
+
+// Widget is a retained UI element that is measured, laid out, updated and drawn every frame.
+// Sizes given to widgets are at a UI scale of 1 and scaled during layout.
+type Widget interface {
+	// Measure returns the preferred size; a zero size hides the widget from layout
+	Measure(ui *UI) (int, int)
+	// Layout assigns the widget its screen rectangle
+	Layout(ui *UI, bounds Rect)
+	Bounds() Rect
+	// HitTest returns the widget that receives the mouse at the point, or nil to let it through
+	HitTest(x, y int) Widget
+	Update(ui *UI)
+	Draw(screen *ebiten.Image, ui *UI)
+}
+
+// tooltipProvider is implemented by widgets that show a tooltip while hovered
+type tooltipProvider interface {
+	Tooltip() string
+}
+
+// UI holds the fonts, the theme and the per-frame mouse state shared by all widgets
+type UI struct {
+	Fonts *FontManager
+	Theme *Theme
+
+	MouseX, MouseY int
+	Pressed        bool
+	JustPressed    bool
+	JustReleased   bool
+	Moved          bool
+
+	// Hovered is the top-most widget under the cursor, nil when the cursor is over the field
+	Hovered Widget
+
+	captured Widget // Widget the current mouse press started on
+	tooltip  string
+	input    *Input
+}
+
+// NewUI creates the UI with the given fonts, drawing with the colors of a theme
+func NewUI(fonts *FontManager, theme *Theme) *UI {
+	fonts.Shadow = theme.TextShadow
+	return &UI{Fonts: fonts, Theme: theme}
+}
+
+// Px scales a layout size by the UI scale
+func (ui *UI) Px(size int) int {
+	return ui.Fonts.Px(size)
+}
+
+// BeginFrame takes this frame's mouse state from the input tracker
+func (ui *UI) BeginFrame(input *Input) {
+	x, y := ebiten.CursorPosition()
+	ui.Moved = x != ui.MouseX || y != ui.MouseY
+	ui.MouseX, ui.MouseY = x, y
+
+	ui.input = input
+	ui.Pressed = input.MouseHeld(ebiten.MouseButtonLeft)
+	ui.JustPressed = input.MouseJustPressed(ebiten.MouseButtonLeft)
+	ui.JustReleased = input.MouseJustReleased(ebiten.MouseButtonLeft)
+}
+
+// Update lays out the root panels and dispatches mouse input; it reports whether the cursor is over the UI
+func (ui *UI) Update(roots ...*Panel) bool {
+	ui.Hovered = nil
+	for _, root := range roots {
+		root.layoutRoot(ui)
+	}
+
+	// Later roots are drawn on top, so they get the mouse first
+	for i := len(roots) - 1; i >= 0 && ui.Hovered == nil; i-- {
+		ui.Hovered = roots[i].HitTest(ui.MouseX, ui.MouseY)
+	}
+
+	if ui.JustPressed {
+		ui.captured = ui.Hovered
+	}
+	for _, root := range roots {
+		root.Update(ui)
+	}
+
+	// Presses on a widget, and the releases that end them, belong to the UI alone
+	if (ui.JustPressed && ui.Hovered != nil) || (ui.JustReleased && ui.captured != nil) {
+		ui.input.ConsumeMouse(ebiten.MouseButtonLeft)
+	}
+	if !ui.Pressed {
+		ui.captured = nil
+	}
+
+	ui.tooltip = ""
+	if provider, ok := ui.Hovered.(tooltipProvider); ok {
+		ui.tooltip = provider.Tooltip()
+	}
+
+	return ui.Hovered != nil
+}
+
+// Clicked reports whether a press that started on the widget was released over it this frame
+func (ui *UI) Clicked(w Widget) bool {
+	return ui.JustReleased && ui.captured == w && ui.Hovered == w
+}
+
+// Draw renders the root panels followed by the tooltip of the hovered widget
+func (ui *UI) Draw(screen *ebiten.Image, roots ...*Panel) {
+	for _, root := range roots {
+		root.layoutRoot(ui)
+		root.Draw(screen, ui)
+	}
+	ui.drawTooltip(screen)
+}
+
+// drawTooltip draws the hovered widget's tooltip next to the cursor, kept on screen
+func (ui *UI) drawTooltip(screen *ebiten.Image) {
+	if ui.tooltip == "" {
+		return
+	}
+
+	textW, textH := ui.Fonts.Measure(TextTooltip, ui.tooltip)
+	pad := ui.Px(6)
+	w, h := textW+2*pad, textH+2*pad
+	x, y := ui.MouseX+ui.Px(14), ui.MouseY-h-ui.Px(6)
+	bounds := screen.Bounds()
+	if x+w > bounds.Dx() {
+		x = bounds.Dx() - w
+	}
+	if y < 0 {
+		y = ui.MouseY + ui.Px(20)
+	}
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), ui.Theme.Tooltip, false)
+	vector.StrokeRect(screen, float32(x), float32(y), float32(w), float32(h), 1, ui.Theme.TooltipBorder, false)
+	ui.Fonts.Draw(screen, TextTooltip, ui.tooltip, x+pad, y+pad, ui.Theme.Text)
+}
+
+// drawCenteredText draws each line of text centered horizontally in a rectangle, starting at top
+func (ui *UI) drawCenteredText(screen *ebiten.Image, style TextStyle, text string, r Rect, top int, clr color.Color) {
+	lineHeight := ui.Fonts.LineHeight(style)
+	for i, line := range strings.Split(text, "\n") {
+		w, _ := ui.Fonts.Measure(style, line)
+		ui.Fonts.Draw(screen, style, line, r.X+(r.W-w)/2, top+i*lineHeight, clr)
+	}
+}
+
+// Panel groups child widgets, stacking them vertically or horizontally
+type Panel struct {
+	Children  []Widget
+	Direction LayoutDirection
+	Align     Align // Horizontal panels can center their row
+	Padding   int
+	Spacing   int
+	MinWidth  int
+
+	// Background and Border are optional; a panel with a background blocks the mouse
+	Background color.Color
+	Border     color.Color
+
+	// PassThrough lets clicks reach the field even over the background
+	PassThrough bool
+
+	// PositionFunc places a root panel given its measured size
+	PositionFunc func(w, h int) (int, int)
+
+	// VisibleFunc hides the panel and its children when it returns false
+	VisibleFunc func() bool
+
+	bounds Rect
+}
+
+// Visible reports whether the panel is currently shown
+func (p *Panel) Visible() bool {
+	return p.VisibleFunc == nil || p.VisibleFunc()
+}
+
+func (p *Panel) Measure(ui *UI) (int, int) {
+	if !p.Visible() {
+		return 0, 0
+	}
+
+	width, height, count := 0, 0, 0
+	for _, child := range p.Children {
+		w, h := child.Measure(ui)
+		if w == 0 && h == 0 {
+			continue
+		}
+		if p.Direction == LayoutHorizontal {
+			width += w
+			height = maxInt(height, h)
+		} else {
+			width = maxInt(width, w)
+			height += h
+		}
+		count++
+	}
+
+	if count > 1 {
+		if p.Direction == LayoutHorizontal {
+			width += ui.Px(p.Spacing) * (count - 1)
+		} else {
+			height += ui.Px(p.Spacing) * (count - 1)
+		}
+	}
+	padding := ui.Px(p.Padding)
+	width = maxInt(width, ui.Px(p.MinWidth)-2*padding)
+	return width + 2*padding, height + 2*padding
+}
+
+// Layout stacks the children; vertical panels stretch children to their full width
+func (p *Panel) Layout(ui *UI, bounds Rect) {
+	p.bounds = bounds
+	if !p.Visible() {
+		return
+	}
+
+	padding := ui.Px(p.Padding)
+	spacing := ui.Px(p.Spacing)
+	x := bounds.X + padding
+	y := bounds.Y + padding
+	innerW := bounds.W - 2*padding
+	innerH := bounds.H - 2*padding
+
+	if p.Direction == LayoutHorizontal && p.Align == AlignCenter {
+		measuredW, _ := p.Measure(ui)
+		x += (bounds.W - measuredW) / 2
+	}
+
+	for _, child := range p.Children {
+		w, h := child.Measure(ui)
+		if w == 0 && h == 0 {
+			child.Layout(ui, Rect{X: x, Y: y})
+			continue
+		}
+		if p.Direction == LayoutHorizontal {
+			child.Layout(ui, Rect{X: x, Y: y, W: w, H: innerH})
+			x += w + spacing
+		} else {
+			child.Layout(ui, Rect{X: x, Y: y, W: innerW, H: h})
+			y += h + spacing
+		}
+	}
+}
+
+// layoutRoot measures and positions a top-level panel
+func (p *Panel) layoutRoot(ui *UI) {
+	w, h := p.Measure(ui)
+	x, y := 0, 0
+	if p.PositionFunc != nil {
+		x, y = p.PositionFunc(w, h)
+	}
+	p.Layout(ui, Rect{X: x, Y: y, W: w, H: h})
+}
+
+func (p *Panel) Bounds() Rect {
+	return p.bounds
+}
+
+func (p *Panel) HitTest(x, y int) Widget {
+	if !p.Visible() || !p.bounds.Contains(x, y) {
+		return nil
+	}
+	for i := len(p.Children) - 1; i >= 0; i-- {
+		if hit := p.Children[i].HitTest(x, y); hit != nil {
+			return hit
+		}
+	}
+	if p.Background != nil && !p.PassThrough {
+		return p
+	}
+	return nil
+}
+
+func (p *Panel) Update(ui *UI) {
+	if !p.Visible() {
+		return
+	}
+	for _, child := range p.Children {
+		child.Update(ui)
+	}
+}
+
+func (p *Panel) Draw(screen *ebiten.Image, ui *UI) {
+	if !p.Visible() || p.bounds.W == 0 {
+		return
+	}
+
+	b := p.bounds
+	if p.Background != nil {
+		vector.DrawFilledRect(screen, float32(b.X), float32(b.Y), float32(b.W), float32(b.H), p.Background, false)
+	}
+	if p.Border != nil {
+		vector.StrokeRect(screen, float32(b.X), float32(b.Y), float32(b.W), float32(b.H), 2, p.Border, false)
+	}
+	for _, child := range p.Children {
+		child.Draw(screen, ui)
+	}
+}
+
+// Label displays static or dynamic text
+type Label struct {
+	Text     string
+	TextFunc func() string
+	Style    TextStyle
+	Align    Align
+	Color    color.Color // Defaults to white
+
+	bounds Rect
+}
+
+// text returns the label's current text
+func (l *Label) text() string {
+	if l.TextFunc != nil {
+		return l.TextFunc()
+	}
+	return l.Text
+}
+
+func (l *Label) Measure(ui *UI) (int, int) {
+	return ui.Fonts.Measure(l.Style, l.text())
+}
+
+func (l *Label) Layout(ui *UI, bounds Rect) {
+	l.bounds = bounds
+}
+
+func (l *Label) Bounds() Rect {
+	return l.bounds
+}
+
+func (l *Label) HitTest(x, y int) Widget {
+	return nil
+}
+
+func (l *Label) Update(ui *UI) {}
+
+func (l *Label) Draw(screen *ebiten.Image, ui *UI) {
+	text := l.text()
+	if text == "" {
+		return
+	}
+
+	clr := l.Color
+	if clr == nil {
+		clr = ui.Theme.Text
+	}
+	if l.Align == AlignCenter {
+		ui.drawCenteredText(screen, l.Style, text, l.bounds, l.bounds.Y, clr)
+		return
+	}
+	ui.Fonts.Draw(screen, l.Style, text, l.bounds.X, l.bounds.Y, clr)
+}
+
+// Button is a clickable widget that fires OnClick when a press is released over it
+type Button struct {
+	Label        string
+	LabelFunc    func() string
+	W, H         int // Fixed size; zero fits the label
+	OnClick      func()
+	EnabledFunc  func() bool
+	SelectedFunc func() bool
+	TooltipFunc  func() string
+
+	bounds Rect
+}
+
+// label returns the button's current caption
+func (b *Button) label() string {
+	if b.LabelFunc != nil {
+		return b.LabelFunc()
+	}
+	return b.Label
+}
+
+// Enabled reports whether the button reacts to clicks
+func (b *Button) Enabled() bool {
+	return b.EnabledFunc == nil || b.EnabledFunc()
+}
+
+func (b *Button) Tooltip() string {
+	if b.TooltipFunc == nil {
+		return ""
+	}
+	return b.TooltipFunc()
+}
+
+func (b *Button) Measure(ui *UI) (int, int) {
+	textW, textH := ui.Fonts.Measure(TextBody, b.label())
+	w, h := ui.Px(b.W), ui.Px(b.H)
+	if w == 0 {
+		w = textW + ui.Px(16)
+	}
+	if h == 0 {
+		h = textH + ui.Px(6)
+	}
+	return w, h
+}
+
+func (b *Button) Layout(ui *UI, bounds Rect) {
+	b.bounds = bounds
+}
+
+func (b *Button) Bounds() Rect {
+	return b.bounds
+}
+
+func (b *Button) HitTest(x, y int) Widget {
+	if b.bounds.Contains(x, y) {
+		return b
+	}
+	return nil
+}
+
+func (b *Button) Update(ui *UI) {
+	if ui.Clicked(b) && b.Enabled() && b.OnClick != nil {
+		b.OnClick()
+	}
+}
+
+func (b *Button) Draw(screen *ebiten.Image, ui *UI) {
+	r := b.bounds
+	fill := ui.Theme.Button
+	border := ui.Theme.ButtonBorder
+	textClr := ui.Theme.Text
+	switch {
+	case !b.Enabled():
+		fill = ui.Theme.ButtonDisabled
+		textClr = ui.Theme.TextDisabled
+	case ui.Hovered == b && ui.Pressed:
+		fill = ui.Theme.ButtonPressed
+	case ui.Hovered == b:
+		fill = ui.Theme.ButtonHover
+	}
+	if b.SelectedFunc != nil && b.SelectedFunc() {
+		border = ui.Theme.ButtonSelected
+	}
+
+	vector.DrawFilledRect(screen, float32(r.X), float32(r.Y), float32(r.W), float32(r.H), fill, false)
+	vector.StrokeRect(screen, float32(r.X), float32(r.Y), float32(r.W), float32(r.H), 1, border, false)
+
+	// Center the caption
+	text := b.label()
+	_, textH := ui.Fonts.Measure(TextBody, text)
+	ui.drawCenteredText(screen, TextBody, text, r, r.Y+(r.H-textH)/2, textClr)
+}
+
+// List is a vertical list of selectable items; hovering selects and clicking activates
+type List struct {
+	Items        func() []string
+	SelectedFunc func() int
+	OnSelect     func(index int)
+	OnActivate   func(index int)
+	Style        TextStyle
+	ItemHeight   int
+	Width        int
+
+	bounds     Rect
+	itemHeight int // Scaled item height from the last layout
+}
+
+// itemAt returns the item index under a screen point, or -1
+func (l *List) itemAt(x, y int) int {
+	if !l.bounds.Contains(x, y) || l.itemHeight <= 0 {
+		return -1
+	}
+	index := (y - l.bounds.Y) / l.itemHeight
+	if index >= len(l.Items()) {
+		return -1
+	}
+	return index
+}
+
+func (l *List) Measure(ui *UI) (int, int) {
+	return ui.Px(l.Width), len(l.Items()) * ui.Px(l.ItemHeight)
+}
+
+func (l *List) Layout(ui *UI, bounds Rect) {
+	l.bounds = bounds
+	l.itemHeight = ui.Px(l.ItemHeight)
+}
+
+func (l *List) Bounds() Rect {
+	return l.bounds
+}
+
+func (l *List) HitTest(x, y int) Widget {
+	if l.itemAt(x, y) >= 0 {
+		return l
+	}
+	return nil
+}
+
+func (l *List) Update(ui *UI) {
+	if ui.Hovered != l {
+		return
+	}
+	index := l.itemAt(ui.MouseX, ui.MouseY)
+
+	// Only follow the mouse when it moves so keyboard navigation isn't overridden
+	if (ui.Moved || ui.JustPressed) && l.OnSelect != nil {
+		l.OnSelect(index)
+	}
+	if ui.Clicked(l) && l.OnActivate != nil {
+		l.OnActivate(index)
+	}
+}
+
+func (l *List) Draw(screen *ebiten.Image, ui *UI) {
+	selected := -1
+	if l.SelectedFunc != nil {
+		selected = l.SelectedFunc()
+	}
+
+	// Leave a gap between the item boxes
+	boxH := l.itemHeight - ui.Px(8)
+	_, textH := ui.Fonts.Measure(l.Style, "M")
+	for i, item := range l.Items() {
+		row := Rect{X: l.bounds.X, Y: l.bounds.Y + i*l.itemHeight, W: l.bounds.W, H: boxH}
+		text := item
+		if i == selected {
+			vector.DrawFilledRect(screen, float32(row.X), float32(row.Y), float32(row.W), float32(row.H),
+				ui.Theme.ListSelected, false)
+			vector.StrokeRect(screen, float32(row.X), float32(row.Y), float32(row.W), float32(row.H),
+				2, ui.Theme.ListSelectedBorder, false)
+			text = "► " + item + " ◄"
+		}
+		ui.drawCenteredText(screen, l.Style, text, row, row.Y+(row.H-textH)/2, ui.Theme.Text)
+	}
+}
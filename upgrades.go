@@ -0,0 +1,71 @@
+package main
+
+import "fmt"
+
+// canUpgrade reports whether a tower has upgrade levels left; support structures do not level up
+func (g *Game) canUpgrade(tower *Tower) bool {
+	return !g.config.IsSupportStructure(tower.Type) && tower.Level < g.config.MaxTowerLevel
+}
+
+// upgradeCost returns the price of the tower's next level
+func (g *Game) upgradeCost(tower *Tower) int {
+	return int(float64(tower.Cost) * g.config.UpgradeCostFactor * float64(tower.Level))
+}
+
+// sellValue returns the refund for selling a tower
+func (g *Game) sellValue(tower *Tower) int {
+	return int(float64(tower.TotalSpent) * g.config.SellRefundRate)
+}
+
+// upgradeTower raises a tower's level if it can be afforded
+func (g *Game) upgradeTower(tower *Tower) {
+	if !g.canUpgrade(tower) {
+		return
+	}
+
+	cost := g.upgradeCost(tower)
+	if g.money < cost && !g.hasInfiniteResources() {
+		return
+	}
+
+	if !g.hasInfiniteResources() {
+		g.money -= cost
+	}
+	tower.TotalSpent += cost
+	tower.Level++
+
+	// Rebuild base stats for the new level, then reapply auras on top
+	_, damage, rangeVal, _ := g.config.GetTowerStats(tower.Type)
+	levelsGained := float64(tower.Level - 1)
+	tower.BaseDamage = int(float64(damage) * (1 + g.config.UpgradeDamageBonus*levelsGained))
+	tower.BaseRange = rangeVal * (1 + g.config.UpgradeRangeBonus*levelsGained)
+	g.recalculateAuras()
+
+	if g.config.DebugMode {
+		fmt.Printf("Upgraded %s tower to level %d for $%d\n", g.config.GetTowerName(tower.Type), tower.Level, cost)
+	}
+}
+
+// sellTower removes a tower and refunds part of what was spent on it
+func (g *Game) sellTower(tower *Tower) {
+	for i, t := range g.towers {
+		if t != tower {
+			continue
+		}
+
+		refund := g.sellValue(tower)
+		if !g.hasInfiniteResources() {
+			g.money += refund
+		}
+		g.towers = append(g.towers[:i], g.towers[i+1:]...)
+		if g.selectedTower == tower {
+			g.selectedTower = nil
+		}
+		g.recalculateAuras()
+
+		if g.config.DebugMode {
+			fmt.Printf("Sold %s tower for $%d\n", g.config.GetTowerName(tower.Type), refund)
+		}
+		return
+	}
+}